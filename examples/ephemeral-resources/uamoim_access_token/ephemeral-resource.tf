@@ -0,0 +1,9 @@
+ephemeral "uamoim_access_token" "ci" {}
+
+# Pass the token to another provider without storing it in the state.
+provider "restapi" {
+  uri = "https://oim.example.com/api/v1"
+  headers = {
+    Authorization = ephemeral.uamoim_access_token.ci.authorization
+  }
+}
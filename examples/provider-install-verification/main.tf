@@ -16,6 +16,8 @@ data "uamoim_shops" "example" {}
 
 data "uamoim_sods" "example" {}
 
+data "uamoim_groups" "example" {}
+
 data "uamoim_coffees" "all" {}
 
 locals {
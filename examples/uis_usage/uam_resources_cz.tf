@@ -1,5 +1,5 @@
 # Create modules from groups
-resource "uam_module" "this" {
+resource "uamoim_module" "this" {
   for_each         = { for g in local.groups : g.name => g }
   application_name = "Application"
   module_name      = each.value.name
@@ -7,38 +7,38 @@ resource "uam_module" "this" {
 }
 
 # Resolve module IDs by name (so downstream resources can use ids)
-data "uam_module_by_name" "mod" {
-  for_each         = uam_module.this
+data "uamoim_module_by_name" "mod" {
+  for_each         = uamoim_module.this
   application_name = "Application"
   module_name      = each.value.module_name
 }
 
 # Assign BISO to each module/group pair
-resource "uam_module_biso" "this" {
+resource "uamoim_module_biso" "this" {
   for_each         = local.group_biso_combinations
   application_name = "Application"
 
-  module_id = data.uam_module_by_name.mod[each.value.group_name].id
-  biso_id   = data.uam_group_by_name.biso[each.key].id
+  module_id = data.uamoim_module_by_name.mod[each.value.group_name].id
+  biso_id   = data.uamoim_group_by_name.biso[each.key].id
 
   reason    = "AV ${each.value.group_name}"
 }
 
 # Resolve BISO group id by name
-data "uam_group_by_name" "biso" {
+data "uamoim_group_by_name" "biso" {
   for_each  = local.group_biso_combinations
   group_name = each.value.biso_name
 }
 
 # Role assignment for each group/role combo
-resource "uam_role_assignment" "this" {
+resource "uamoim_role_assignment" "this" {
   for_each         = local.group_role_combinations
   application_name = "Application"
 
-  module_id     = data.uam_module_by_name.mod[each.value.module_name].id
-  group_id      = data.uam_group_by_name.role[each.key].id
-  shop_id       = data.uam_shop_by_name.shop[each.key].id
-  sod_class_id  = data.uam_sod_class_by_name.sod[each.key].id
+  module_id     = data.uamoim_module_by_name.mod[each.value.module_name].id
+  group_id      = data.uamoim_group_by_name.role[each.key].id
+  shop_id       = data.uamoim_shop_by_name.shop[each.key].id
+  sod_class_id  = data.uamoim_sod_class_by_name.sod[each.key].id
 
   order_for     = each.value.order_for
   approval_flow = each.value.approval_flow
@@ -47,17 +47,17 @@ resource "uam_role_assignment" "this" {
 }
 
 # Lookups used by role assignments
-data "uam_group_by_name" "role" {
+data "uamoim_group_by_name" "role" {
   for_each   = local.group_role_combinations
   group_name = each.value.role_name
 }
 
-data "uam_shop_by_name" "shop" {
+data "uamoim_shop_by_name" "shop" {
   for_each = local.group_role_combinations
   shop_name = each.value.shop_name
 }
 
-data "uam_sod_class_by_name" "sod" {
+data "uamoim_sod_class_by_name" "sod" {
   for_each    = local.group_role_combinations
   sod_class_name = "Keine SoD Relevanz"
 }
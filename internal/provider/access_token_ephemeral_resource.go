@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &accessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &accessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithRenew     = &accessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &accessTokenEphemeralResource{}
+)
+
+// accessTokenRenewBefore is how long before its expiry a token is renewed.
+const accessTokenRenewBefore = time.Minute
+
+// accessTokenPrivateKey is the private data key the token is kept under
+// between Open, Renew and Close.
+const accessTokenPrivateKey = "token"
+
+// NewAccessTokenEphemeralResource is a helper function to simplify the provider implementation.
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &accessTokenEphemeralResource{}
+}
+
+// accessTokenEphemeralResource is the ephemeral resource implementation.
+type accessTokenEphemeralResource struct {
+	client *uamclient.Client
+}
+
+// accessTokenEphemeralResourceModel maps the ephemeral resource schema data.
+type accessTokenEphemeralResourceModel struct {
+	Token         types.String `tfsdk:"token"`
+	Authorization types.String `tfsdk:"authorization"`
+	ExpiresAt     types.String `tfsdk:"expires_at"`
+}
+
+// accessTokenPrivate is the private data of an open token. Renew replaces
+// it, as the API may rotate the token.
+type accessTokenPrivate struct {
+	Value string `json:"value"`
+	// Expiry is zero if the API did not say when the token expires.
+	Expiry time.Time `json:"expiry,omitzero"`
+}
+
+func (r *accessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = data.Client
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *accessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *accessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Issues a short-lived UAM/OIM API access token with the credentials of the provider, " +
+			"e.g. for other providers or provisioners. The token is never stored in the state. " +
+			"Session tokens are renewed while Terraform uses them and signed out afterwards; " +
+			"OAuth2 tokens are left to expire.",
+		Attributes: map[string]schema.Attribute{
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The access token.",
+			},
+			"authorization": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The value of the Authorization header to send the token in, e.g. \"Bearer <token>\" for OAuth2 tokens.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the token expires, in RFC 3339 format. Null if the API does not tell.",
+			},
+		},
+	}
+}
+
+// Open issues the token.
+func (r *accessTokenEphemeralResource) Open(ctx context.Context, _ ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	token, err := r.client.Tokens.Issue(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Issuing uamoim Access Token", "Could not issue an access token", err, nil)
+		return
+	}
+
+	result := accessTokenEphemeralResourceModel{
+		Token:         types.StringValue(token.Value),
+		Authorization: types.StringValue(token.Authorization),
+		ExpiresAt:     types.StringNull(),
+	}
+	if !token.Expiry.IsZero() {
+		result.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(writeAccessTokenPrivate(ctx, resp.Private, token)...)
+	if renewAt, ok := accessTokenRenewAt(token); ok {
+		resp.RenewAt = renewAt
+	}
+}
+
+// Renew extends the lifetime of the token while Terraform still uses it and
+// keeps the token it was renewed to for the next Renew and Close.
+func (r *accessTokenEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	private, diags := readAccessTokenPrivate(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := r.client.Tokens.Refresh(ctx, private.Value)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Renewing uamoim Access Token", "Could not renew the access token", err, nil)
+		return
+	}
+	resp.Diagnostics.Append(writeAccessTokenPrivate(ctx, resp.Private, token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if renewAt, ok := accessTokenRenewAt(token); ok {
+		resp.RenewAt = renewAt
+	}
+}
+
+// Close revokes the token once Terraform no longer needs it. A token that
+// already expired needs no revocation.
+func (r *accessTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := readAccessTokenPrivate(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !private.Expiry.IsZero() && time.Now().After(private.Expiry) {
+		return
+	}
+
+	err := r.client.Tokens.Revoke(ctx, private.Value)
+	if err != nil && !uamclient.IsNotFound(err) && !errors.Is(err, uamclient.ErrUnauthorized) {
+		addAPIError(&resp.Diagnostics, "Error Revoking uamoim Access Token", "Could not revoke the access token", err, nil)
+	}
+}
+
+// accessTokenRenewAt returns when token should be renewed, if it can be.
+func accessTokenRenewAt(token *uamclient.Token) (time.Time, bool) {
+	if !token.Renewable || token.Expiry.IsZero() {
+		return time.Time{}, false
+	}
+	return token.Expiry.Add(-accessTokenRenewBefore), true
+}
+
+// privateGetter is implemented by the private data of the Renew and Close
+// requests.
+type privateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateSetter is implemented by the private data of the Open and Renew
+// responses.
+type privateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func writeAccessTokenPrivate(ctx context.Context, p privateSetter, token *uamclient.Token) diag.Diagnostics {
+	var diags diag.Diagnostics
+	b, err := json.Marshal(accessTokenPrivate{Value: token.Value, Expiry: token.Expiry})
+	if err != nil {
+		diags.AddError("Error Encoding uamoim Access Token", "Could not encode private data: "+err.Error())
+		return diags
+	}
+	return p.SetKey(ctx, accessTokenPrivateKey, b)
+}
+
+func readAccessTokenPrivate(ctx context.Context, p privateGetter) (accessTokenPrivate, diag.Diagnostics) {
+	var private accessTokenPrivate
+	b, diags := p.GetKey(ctx, accessTokenPrivateKey)
+	if diags.HasError() {
+		return private, diags
+	}
+	if err := json.Unmarshal(b, &private); err != nil || private.Value == "" {
+		diags.AddError(
+			"Invalid uamoim Access Token Private Data",
+			"The private data of the access token is missing or invalid. Please report this issue to the provider developers.",
+		)
+	}
+	return private, diags
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAccessTokenEphemeralResource(t *testing.T) {
+	signedOut := map[string]bool{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"token": "session-token", "expires_in": 900})
+	})
+	mux.HandleFunc("POST /signout", func(w http.ResponseWriter, r *http.Request) {
+		signedOut[r.Header.Get("Authorization")] = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resource.Test(t, resource.TestCase{
+		// Ephemeral resources are only available in 1.10 and later
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccessTokenEphemeralResourceConfig(srv.URL),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("token"),
+						knownvalue.StringExact("session-token"),
+					),
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("expires_at"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+		},
+	})
+
+	if !signedOut["session-token"] {
+		t.Error("access token was not revoked")
+	}
+}
+
+func testAccAccessTokenEphemeralResourceConfig(host string) string {
+	return fmt.Sprintf(`
+provider "uamoim" {
+  host     = %[1]q
+  username = "education"
+  password = "test123"
+}
+
+ephemeral "uamoim_access_token" "test" {}
+
+provider "echo" {
+  data = ephemeral.uamoim_access_token.test
+}
+
+resource "echo" "test" {}
+`, host)
+}
+
+// The API rotates the token on every renewal, so Close must revoke the token
+// of the last Renew.
+func TestAccessTokenEphemeralResourceRenew(t *testing.T) {
+	sessions := map[string]bool{}
+	issued := 0
+	mux := http.NewServeMux()
+	issue := func(w http.ResponseWriter) {
+		issued++
+		token := fmt.Sprintf("session-%d", issued)
+		sessions[token] = true
+		writeTestJSON(t, w, http.StatusOK, map[string]any{"token": token, "expires_in": 900})
+	}
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		issue(w)
+	})
+	mux.HandleFunc("POST /signin/refresh", func(w http.ResponseWriter, r *http.Request) {
+		if !sessions[r.Header.Get("Authorization")] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		delete(sessions, r.Header.Get("Authorization"))
+		issue(w)
+	})
+	mux.HandleFunc("POST /signout", func(w http.ResponseWriter, r *http.Request) {
+		if !sessions[r.Header.Get("Authorization")] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		delete(sessions, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	ctx := t.Context()
+	server := configuredProviderServer(t, map[string]tftypes.Value{
+		"host":     tftypes.NewValue(tftypes.String, srv.URL),
+		"username": tftypes.NewValue(tftypes.String, "education"),
+		"password": tftypes.NewValue(tftypes.String, "test123"),
+	})
+	config := ephemeralConfig(t, server, "uamoim_access_token")
+
+	opened, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "uamoim_access_token",
+		Config:   config,
+	})
+	if err != nil || len(opened.Diagnostics) > 0 {
+		t.Fatalf("Open: %v %v", err, opened.Diagnostics)
+	}
+	if opened.RenewAt.IsZero() {
+		t.Fatal("Open: the session token is not renewed")
+	}
+	private := opened.Private
+	for range 2 {
+		renewed, err := server.RenewEphemeralResource(ctx, &tfprotov6.RenewEphemeralResourceRequest{
+			TypeName: "uamoim_access_token",
+			Private:  private,
+		})
+		if err != nil || len(renewed.Diagnostics) > 0 {
+			t.Fatalf("Renew: %v %v", err, renewed.Diagnostics)
+		}
+		private = renewed.Private
+	}
+	closed, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: "uamoim_access_token",
+		Private:  private,
+	})
+	if err != nil || len(closed.Diagnostics) > 0 {
+		t.Fatalf("Close: %v %v", err, closed.Diagnostics)
+	}
+
+	if issued != 3 {
+		t.Errorf("issued %d tokens, want 3", issued)
+	}
+	if len(sessions) != 0 {
+		t.Errorf("sessions %v were not revoked", sessions)
+	}
+}
+
+// configuredProviderServer returns a protocol server of the provider
+// configured with the given attributes, all others null.
+func configuredProviderServer(t *testing.T, attrs map[string]tftypes.Value) tfprotov6.ProviderServer {
+	t.Helper()
+	ctx := t.Context()
+	p := New("test")()
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	config, err := tfprotov6.NewDynamicValue(schemaResp.Schema.Type().TerraformType(ctx),
+		objectWith(schemaResp.Schema.Type().TerraformType(ctx), attrs))
+	if err != nil {
+		t.Fatalf("provider config: %v", err)
+	}
+	server := providerserver.NewProtocol6(p)()
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("ConfigureProvider: %v %v", err, resp.Diagnostics)
+	}
+	return server
+}
+
+// ephemeralConfig returns an empty configuration of the ephemeral resource
+// typeName.
+func ephemeralConfig(t *testing.T, server tfprotov6.ProviderServer, typeName string) *tfprotov6.DynamicValue {
+	t.Helper()
+	schemas, err := server.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	typ := schemas.EphemeralResourceSchemas[typeName].ValueType()
+	config, err := tfprotov6.NewDynamicValue(typ, objectWith(typ, nil))
+	if err != nil {
+		t.Fatalf("ephemeral config: %v", err)
+	}
+	return &config
+}
+
+// objectWith returns an object of type typ with the given attributes and all
+// others null.
+func objectWith(typ tftypes.Type, attrs map[string]tftypes.Value) tftypes.Value {
+	obj, ok := typ.(tftypes.Object)
+	if !ok {
+		panic(fmt.Sprintf("not an object type: %s", typ))
+	}
+	values := map[string]tftypes.Value{}
+	for name, attrType := range obj.AttributeTypes {
+		if v, ok := attrs[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	return tftypes.NewValue(typ, values)
+}
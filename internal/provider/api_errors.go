@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// addAPIError adds err, returned by the API client, to diags. Validation
+// errors for the request fields listed in fields are attached to the
+// matching attribute, so that Terraform points at the offending line. All
+// other errors become a general error with a hint for the kind of failure.
+func addAPIError(diags *diag.Diagnostics, summary, detail string, err error, fields map[string]path.Path) {
+	if fieldErrs := uamclient.FieldErrors(err); len(fieldErrs) > 0 {
+		unmapped := false
+		for _, fe := range fieldErrs {
+			p, ok := fields[fe.Field]
+			if !ok {
+				unmapped = true
+				continue
+			}
+			diags.AddAttributeError(p, summary, fmt.Sprintf("%s: the API rejected the value: %s", detail, fe.Message))
+		}
+		if !unmapped {
+			return
+		}
+	}
+	diags.AddError(summary, detail+": "+err.Error()+apiErrorHint(err))
+}
+
+// apiErrorHint returns advice for the kind of err, if there is any.
+func apiErrorHint(err error) string {
+	switch {
+	case errors.Is(err, uamclient.ErrUnauthorized):
+		return "\n\nThe API rejected the provider credentials. Check the provider authentication settings."
+	case errors.Is(err, uamclient.ErrForbidden):
+		return "\n\nThe provider credentials are not permitted to perform this operation."
+	case errors.Is(err, uamclient.ErrConflict):
+		return "\n\nThe object already exists or was changed concurrently. Refresh the state and try again."
+	case errors.Is(err, uamclient.ErrRateLimited):
+		return "\n\nThe API is throttling requests. Try again later or reduce the parallelism."
+	case errors.Is(err, uamclient.ErrReadOnly):
+		return "\n\nThe provider is read-only. Unset read_only in the provider configuration, or UAMOIM_READ_ONLY, to apply changes."
+	case errors.Is(err, uamclient.ErrNotFound):
+		return "\n\nThe object or one of the objects it references does not exist."
+	default:
+		return ""
+	}
+}
+
+// rootPaths maps each attribute name to its root path. It is used for the
+// fields argument of addAPIError when API fields and attributes share names.
+func rootPaths(names ...string) map[string]path.Path {
+	paths := make(map[string]path.Path, len(names))
+	for _, name := range names {
+		paths[name] = path.Root(name)
+	}
+	return paths
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+func TestAddAPIErrorMapsValidationFields(t *testing.T) {
+	err := &uamclient.APIError{
+		StatusCode: 422,
+		Message:    "validation failed",
+		FieldErrors: []uamclient.FieldError{
+			{Field: "approval_flow", Message: "unknown approval flow"},
+		},
+	}
+
+	var diags diag.Diagnostics
+	addAPIError(&diags, "Error Creating uamoim Role Assignment", "Could not assign group", err, roleAssignmentAPIFields)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("got %d errors, want 1", diags.ErrorsCount())
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("approval_flow")) {
+		t.Errorf("diagnostic is not attached to approval_flow: %v", diags.Errors()[0])
+	}
+}
+
+func TestAddAPIErrorFallsBackToGeneralError(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		hint string
+	}{
+		"unmapped field": {
+			err: &uamclient.APIError{
+				StatusCode:  400,
+				FieldErrors: []uamclient.FieldError{{Field: "tenant", Message: "required"}},
+			},
+		},
+		"forbidden": {
+			err:  &uamclient.APIError{StatusCode: 403},
+			hint: "not permitted",
+		},
+		"rate limited": {
+			err:  &uamclient.APIError{StatusCode: 429},
+			hint: "throttling",
+		},
+	}
+	for name, tt := range tests {
+		var diags diag.Diagnostics
+		addAPIError(&diags, "Error", "Could not do it", tt.err, roleAssignmentAPIFields)
+		if diags.ErrorsCount() != 1 {
+			t.Fatalf("%s: got %d errors, want 1", name, diags.ErrorsCount())
+		}
+		d := diags.Errors()[0]
+		if _, ok := d.(diag.DiagnosticWithPath); ok {
+			t.Errorf("%s: unexpected attribute error", name)
+		}
+		if !strings.Contains(d.Detail(), tt.hint) {
+			t.Errorf("%s: detail %q does not contain %q", name, d.Detail(), tt.hint)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// applicationNameAttribute returns the schema of the application_name
+// attribute of a resource that is replaced when the application changes.
+// Resources using it call planApplicationName in ModifyPlan.
+func applicationNameAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: description + " Defaults to the default_application of the provider.",
+		PlanModifiers: []planmodifier.String{
+			// A change of the default is detected by planApplicationName.
+			stringplanmodifier.RequiresReplaceIfConfigured(),
+		},
+	}
+}
+
+// planApplicationName plans the default application of the provider for
+// application_name where the configuration omits it, and replaces the
+// resource if that changes its application.
+func planApplicationName(ctx context.Context, defaultApplication string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	attr := path.Root("application_name")
+	var config types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attr, &config)...)
+	if resp.Diagnostics.HasError() || !config.IsNull() {
+		return
+	}
+
+	application, diags := effectiveApplication(config, defaultApplication)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attr, application)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, attr, &state)...)
+	if !state.Equal(application) {
+		resp.RequiresReplace = append(resp.RequiresReplace, attr)
+	}
+}
+
+// effectiveApplication returns the configured application_name, or the
+// default application of the provider if it is omitted.
+func effectiveApplication(config types.String, defaultApplication string) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !config.IsNull() {
+		return config, diags
+	}
+	if defaultApplication == "" {
+		diags.AddAttributeError(
+			path.Root("application_name"),
+			"Missing Application Name",
+			"application_name is not set and the provider has no default_application. "+
+				"Set application_name, or default_application in the provider configuration.",
+		)
+		return config, diags
+	}
+	return types.StringValue(defaultApplication), diags
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEffectiveApplication(t *testing.T) {
+	tests := map[string]struct {
+		config             types.String
+		defaultApplication string
+		want               types.String
+		wantErr            bool
+	}{
+		"configured":             {types.StringValue("Application"), "Default", types.StringValue("Application"), false},
+		"configured, no default": {types.StringValue("Application"), "", types.StringValue("Application"), false},
+		"default":                {types.StringNull(), "Default", types.StringValue("Default"), false},
+		"neither":                {types.StringNull(), "", types.StringNull(), true},
+		"unknown, not defaulted": {types.StringUnknown(), "Default", types.StringUnknown(), false},
+	}
+	for name, tt := range tests {
+		got, diags := effectiveApplication(tt.config, tt.defaultApplication)
+		if diags.HasError() != tt.wantErr {
+			t.Errorf("%s: diagnostics = %v, want error %v", name, diags, tt.wantErr)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: effectiveApplication = %s, want %s", name, got, tt.want)
+		}
+	}
+}
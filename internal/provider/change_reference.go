@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// changeReferenceAttribute returns the schema of the change_reference
+// attribute. Resources using it call planChangeReference in ModifyPlan and
+// send the planned reference with uamclient.WithChangeReference.
+func changeReferenceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Description: "The change reference, e.g. a ticket key, sent with every create, update and delete of the object " +
+			"for the audit trail of UAM/OIM. Defaults to the change_reference of the provider. " +
+			"The state keeps the reference of the last change Terraform made.",
+	}
+}
+
+// planChangeReference plans the change reference of the provider for
+// change_reference where the configuration omits it and the object is
+// created, changed or replaced. Objects without changes keep the reference
+// of their last change.
+func planChangeReference(ctx context.Context, changeReference string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	attr := path.Root("change_reference")
+	var config, plan types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attr, &config)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, attr, &plan)...)
+	if resp.Diagnostics.HasError() || !config.IsNull() {
+		return
+	}
+	// The framework marks the attribute unknown if anything else changes.
+	if !plan.IsUnknown() && len(resp.RequiresReplace) == 0 {
+		return
+	}
+
+	value := types.StringNull()
+	if changeReference != "" {
+		value = types.StringValue(changeReference)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attr, value)...)
+}
+
+// deleteChangeReference returns ctx with the change reference for deleting
+// an object: that of the provider, as the configuration of the object is
+// gone, or else the reference of its last change in state.
+func deleteChangeReference(ctx context.Context, changeReference string, state types.String) context.Context {
+	if changeReference == "" {
+		changeReference = state.ValueString()
+	}
+	return uamclient.WithChangeReference(ctx, changeReference)
+}
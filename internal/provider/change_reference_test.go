@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPlanChangeReference(t *testing.T) {
+	ctx := t.Context()
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":             schema.StringAttribute{Required: true},
+			"change_reference": changeReferenceAttribute(),
+		},
+	}
+	objectType := s.Type().TerraformType(ctx)
+	object := func(name, ref tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{"name": name, "change_reference": ref})
+	}
+	str := func(v string) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	null := tftypes.NewValue(tftypes.String, nil)
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	tests := map[string]struct {
+		config, plan, state tftypes.Value
+		changeReference     string
+		want                types.String
+	}{
+		"create with provider reference": {
+			config: object(str("a"), null), plan: object(str("a"), unknown), state: tftypes.NewValue(objectType, nil),
+			changeReference: "UGITLAB-3", want: types.StringValue("UGITLAB-3"),
+		},
+		"create without reference": {
+			config: object(str("a"), null), plan: object(str("a"), unknown), state: tftypes.NewValue(objectType, nil),
+			want: types.StringNull(),
+		},
+		"update keeps override": {
+			config: object(str("b"), str("UGITLAB-7")), plan: object(str("b"), str("UGITLAB-7")), state: object(str("a"), str("UGITLAB-3")),
+			changeReference: "UGITLAB-4", want: types.StringValue("UGITLAB-7"),
+		},
+		"update with provider reference": {
+			config: object(str("b"), null), plan: object(str("b"), unknown), state: object(str("a"), str("UGITLAB-3")),
+			changeReference: "UGITLAB-4", want: types.StringValue("UGITLAB-4"),
+		},
+		"no change keeps last reference": {
+			config: object(str("a"), null), plan: object(str("a"), str("UGITLAB-3")), state: object(str("a"), str("UGITLAB-3")),
+			changeReference: "UGITLAB-4", want: types.StringValue("UGITLAB-3"),
+		},
+	}
+	for name, tt := range tests {
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: s, Raw: tt.config},
+			Plan:   tfsdk.Plan{Schema: s, Raw: tt.plan},
+			State:  tfsdk.State{Schema: s, Raw: tt.state},
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		planChangeReference(ctx, tt.changeReference, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", name, resp.Diagnostics)
+		}
+		var got types.String
+		resp.Plan.GetAttribute(ctx, path.Root("change_reference"), &got)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: change_reference = %s, want %s", name, got, tt.want)
+		}
+	}
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp-demoapp/hashicups-client-go"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -40,22 +41,22 @@ func NewCoffeesDataSource() datasource.DataSource {
 }
 
 type coffeesDataSource struct {
-	client *hashicups.Client
+	client *uamclient.Client
 }
 
 func (d *coffeesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*hashicups.Client)
+	data, ok := req.ProviderData.(*uamoimProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	d.client = client
+	d.client = data.Client
 }
 
 func (d *coffeesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -116,11 +117,13 @@ func (d *coffeesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 
 func (d *coffeesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state coffeesDataSourceModel
-	coffees, err := d.client.GetCoffees()
+	coffees, err := d.client.Coffees.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Unable to Read HashiCups Coffees",
-			err.Error(),
+			"Could not list coffees",
+			err, nil,
 		)
 		return
 	}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &groupByNameDataSource{}
+	_ datasource.DataSourceWithConfigure = &groupByNameDataSource{}
+)
+
+type groupByNameDataSourceModel struct {
+	GroupName   types.String `tfsdk:"group_name"`
+	ID          types.String `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+}
+
+func NewGroupByNameDataSource() datasource.DataSource {
+	return &groupByNameDataSource{}
+}
+
+type groupByNameDataSource struct {
+	client *uamclient.Client
+}
+
+func (d *groupByNameDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = data.Client
+}
+
+func (d *groupByNameDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_by_name"
+}
+
+func (d *groupByNameDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single group, e.g. a role group or a BISO, by its name.",
+		Attributes: map[string]schema.Attribute{
+			"group_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The exact name of the group.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the group.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "The description of the group.",
+			},
+		},
+	}
+}
+
+func (d *groupByNameDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state groupByNameDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := d.client.Groups.List(ctx, &uamclient.GroupListOptions{
+		Name: state.GroupName.ValueString(),
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Unable to Read uamoim Groups",
+			"Could not list groups named "+state.GroupName.ValueString(),
+			err, nil,
+		)
+		return
+	}
+
+	group, diags := lookupByName(groups, state.GroupName.ValueString(),
+		func(g uamclient.Group) string { return g.Name },
+		func(g uamclient.Group) string { return g.ID },
+		path.Root("group_name"), "Group")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(group.ID)
+	state.Description = types.StringValue(group.Description)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+func TestGroupByNameDataSourceRead(t *testing.T) {
+	// The API matches names loosely, so all groups are served.
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/groups", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(t, w, http.StatusOK, map[string]any{"items": []uamclient.Group{
+			{ID: "g-1", Name: "App.Application.PROD.oska.Leser", Description: "Readers"},
+			{ID: "g-2", Name: "App.Application.PROD.oska.Leser.Extern"},
+			{ID: "g-3", Name: "XZ41234"},
+			{ID: "g-4", Name: "XZ41234"},
+		}})
+	})
+	data := newTestProviderData(t, mux)
+	tests := map[string]struct{ want, wantErr string }{
+		"App.Application.PROD.oska.Leser": {want: "g-1"},
+		"App.Application.PROD.oska":       {wantErr: "Group Not Found"},
+		"XZ41234":                         {wantErr: "Ambiguous Group Name"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := readDataSource(t, NewGroupByNameDataSource(), data, &groupByNameDataSourceModel{
+				GroupName:   types.StringValue(name),
+				ID:          types.StringNull(),
+				Description: types.StringNull(),
+			})
+			if tt.wantErr != "" {
+				if got := attributeError(resp.Diagnostics, path.Root("group_name")); got != tt.wantErr {
+					t.Errorf("error on group_name = %q, want %q (%v)", got, tt.wantErr, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read: %v", resp.Diagnostics)
+			}
+			var got groupByNameDataSourceModel
+			getState(t, resp.State, &got)
+			if got.ID.ValueString() != tt.want || got.Description.ValueString() != "Readers" {
+				t.Errorf("got group %s (%s), want %s", got.ID, got.Description, tt.want)
+			}
+		})
+	}
+}
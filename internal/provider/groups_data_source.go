@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &groupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &groupsDataSource{}
+)
+
+type groupsDataSourceModel struct {
+	Groups []groupsModel           `tfsdk:"groups"`
+	ByName map[string]types.String `tfsdk:"by_name"`
+}
+
+type groupsModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+func NewGroupsDataSource() datasource.DataSource {
+	return &groupsDataSource{}
+}
+
+type groupsDataSource struct {
+	client *uamclient.Client
+}
+
+func (d *groupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = data.Client
+}
+
+func (d *groupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+func (d *groupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all role groups.",
+		Attributes: map[string]schema.Attribute{
+			"groups": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"by_name": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "A map of group IDs by their name. Of several groups with the same name, it holds the first one listed.",
+			},
+		},
+	}
+}
+
+func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state groupsDataSourceModel
+	state.Groups = []groupsModel{}
+	byName := newNameMap()
+	for group, err := range d.client.Groups.All(ctx, nil) {
+		if err != nil {
+			addAPIError(
+				&resp.Diagnostics,
+				"Unable to Read uamoim Groups",
+				"Could not list groups",
+				err, nil,
+			)
+			return
+		}
+		state.Groups = append(state.Groups, groupsModel{
+			ID:          types.StringValue(group.ID),
+			Name:        types.StringValue(group.Name),
+			Description: types.StringValue(group.Description),
+		})
+		byName.add(group.Name, group.ID)
+	}
+	state.ByName = byName.ids
+	byName.warnDuplicates(&resp.Diagnostics, "Group", "groups", "the groups list")
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
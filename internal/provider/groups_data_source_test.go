@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+func TestGroupsDataSourceRead(t *testing.T) {
+	pages := map[string]struct {
+		items []uamclient.Group
+		next  string
+	}{
+		"": {
+			items: []uamclient.Group{
+				{ID: "g-1", Name: "App.Application.PROD.oska.Leser", Description: "Readers"},
+				{ID: "g-2", Name: "XZ41234"},
+			},
+			next: "/api/v1/groups?cursor=2",
+		},
+		"2": {
+			items: []uamclient.Group{
+				{ID: "g-3", Name: "XZ41234", Description: "Renamed later"},
+				{ID: "g-4", Name: "App.Application.PROD.oska.Schreiber"},
+			},
+		},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/groups", func(w http.ResponseWriter, r *http.Request) {
+		pg, ok := pages[r.URL.Query().Get("cursor")]
+		if !ok {
+			t.Errorf("unexpected page %s", r.URL)
+		}
+		writeTestJSON(t, w, http.StatusOK, map[string]any{"items": pg.items, "next": pg.next})
+	})
+	d := NewGroupsDataSource()
+	s := configureDataSource(t, d, newTestProviderData(t, mux))
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	d.Read(t.Context(), datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: nullValue(s)}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), `"XZ41234"`) {
+		t.Errorf("expected a warning about the duplicate name, got %v", resp.Diagnostics)
+	}
+
+	var state groupsDataSourceModel
+	getState(t, resp.State, &state)
+	want := append(pages[""].items, pages["2"].items...)
+	if len(state.Groups) != len(want) {
+		t.Fatalf("got %d groups, want %d from both pages", len(state.Groups), len(want))
+	}
+	for i, g := range want {
+		got := state.Groups[i]
+		if got.ID.ValueString() != g.ID || got.Name.ValueString() != g.Name || got.Description.ValueString() != g.Description {
+			t.Errorf("groups[%d] = %+v, want %+v", i, got, g)
+		}
+	}
+	// All groups are listed, but by_name keeps the first of a name.
+	if len(state.ByName) != 3 || state.ByName["XZ41234"].ValueString() != "g-2" ||
+		state.ByName["App.Application.PROD.oska.Schreiber"].ValueString() != "g-4" {
+		t.Errorf("by_name = %v", state.ByName)
+	}
+}
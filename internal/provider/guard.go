@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// allowedHosts returns the hosts of allowed_hosts or UAMOIM_ALLOWED_HOSTS,
+// or nil if any host is allowed. allowed_hosts must not be empty.
+func allowedHosts(ctx context.Context, cfg uamoimProviderConfig, env environment, diags *diag.Diagnostics) []string {
+	if cfg.AllowedHosts.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("allowed_hosts"),
+			"Unknown uamoim Allowed Hosts",
+			"The provider cannot check the uamoim API host as allowed_hosts is unknown. "+
+				"Set the value statically in the configuration.",
+		)
+		return nil
+	}
+	if cfg.AllowedHosts.IsNull() {
+		return strings.FieldsFunc(env.get("UAMOIM_ALLOWED_HOSTS"), func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+	}
+	var hosts []string
+	diags.Append(cfg.AllowedHosts.ElementsAs(ctx, &hosts, false)...)
+	if len(hosts) == 0 && !diags.HasError() {
+		// An empty list would silently allow any host.
+		diags.AddAttributeError(
+			path.Root("allowed_hosts"),
+			"Empty uamoim Allowed Hosts",
+			"allowed_hosts must list at least one host. Remove the attribute to allow any host.",
+		)
+	}
+	return hosts
+}
+
+// checkAllowedHost adds an error to diags unless host is in allowed. An
+// entry matches the host name of host, or its host and port. An empty list
+// allows any host.
+func checkAllowedHost(host string, allowed []string, diags *diag.Diagnostics) {
+	if len(allowed) == 0 {
+		return
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		// NewClient reports the invalid host.
+		return
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(a, u.Hostname()) || strings.EqualFold(a, u.Host) {
+			return
+		}
+	}
+	diags.AddAttributeError(
+		path.Root("host"),
+		"uamoim API Host Not Allowed",
+		fmt.Sprintf("The uamoim API host %q is not in allowed_hosts (%s). "+
+			"Check the host value in the configuration and the UAMOIM_HOST environment variable.",
+			host, strings.Join(allowed, ", ")),
+	)
+}
+
+// expectedEnvironment returns expected_environment or
+// UAMOIM_EXPECTED_ENVIRONMENT, which must not be unknown.
+func expectedEnvironment(cfg uamoimProviderConfig, env environment, diags *diag.Diagnostics) string {
+	if cfg.ExpectedEnvironment.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("expected_environment"),
+			"Unknown uamoim Expected Environment",
+			"The provider cannot check the environment of the uamoim API as expected_environment is unknown. "+
+				"Set the value statically in the configuration.",
+		)
+		return ""
+	}
+	return env.value(cfg.ExpectedEnvironment, "UAMOIM_EXPECTED_ENVIRONMENT")
+}
+
+// checkEnvironment adds an error to diags unless the server the client talks
+// to reports the expected environment. The comparison ignores case.
+func checkEnvironment(ctx context.Context, client *uamclient.Client, expected string, diags *diag.Diagnostics) {
+	if expected == "" {
+		return
+	}
+	info, err := client.Server.Info(ctx)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("expected_environment"),
+			"Unable to Verify uamoim Environment",
+			"The provider cannot read the environment of the uamoim API to compare it with expected_environment: "+
+				err.Error()+apiErrorHint(err),
+		)
+		return
+	}
+	if !strings.EqualFold(info.Environment, expected) {
+		diags.AddAttributeError(
+			path.Root("expected_environment"),
+			"Unexpected uamoim Environment",
+			fmt.Sprintf("The provider expects the %s environment, but the uamoim API at the configured host reports %q. "+
+				"Check the host value in the configuration and the UAMOIM_HOST environment variable.",
+				expected, info.Environment),
+		)
+	}
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+func TestCheckAllowedHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		allowed []string
+		wantErr bool
+	}{
+		{"https://oim-prod.example.com", nil, false},
+		{"https://oim-prod.example.com", []string{"oim-test.example.com", "OIM-PROD.example.com"}, false},
+		{"https://oim-prod.example.com:8443", []string{"oim-prod.example.com:8443"}, false},
+		{"https://oim-prod.example.com:8443", []string{"oim-prod.example.com:443"}, true},
+		{"https://oim-prod.example.com", []string{"oim-test.example.com"}, true},
+	}
+	for _, tt := range tests {
+		var diags diag.Diagnostics
+		checkAllowedHost(tt.host, tt.allowed, &diags)
+		if diags.HasError() != tt.wantErr {
+			t.Errorf("checkAllowedHost(%q, %q) = %v, want error %t", tt.host, tt.allowed, diags, tt.wantErr)
+		}
+	}
+}
+
+func TestAllowedHosts(t *testing.T) {
+	t.Setenv("UAMOIM_ALLOWED_HOSTS", "oim-test.example.com, oim-dev.example.com")
+	ctx := t.Context()
+
+	var diags diag.Diagnostics
+	cfg := uamoimProviderConfig{AllowedHosts: types.ListNull(types.StringType)}
+	if got := allowedHosts(ctx, cfg, environment{}, &diags); len(got) != 2 || got[1] != "oim-dev.example.com" {
+		t.Errorf("allowedHosts from environment = %q", got)
+	}
+
+	// The attribute takes precedence over the environment.
+	cfg.AllowedHosts = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("oim.example.com")})
+	if got := allowedHosts(ctx, cfg, environment{}, &diags); len(got) != 1 || got[0] != "oim.example.com" {
+		t.Errorf("allowedHosts = %q, want oim.example.com", got)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	for _, invalid := range []types.List{types.ListValueMust(types.StringType, nil), types.ListUnknown(types.StringType)} {
+		var diags diag.Diagnostics
+		cfg.AllowedHosts = invalid
+		if allowedHosts(ctx, cfg, environment{}, &diags); !diags.HasError() {
+			t.Errorf("expected error for allowed_hosts = %s", invalid)
+		}
+	}
+}
+
+func TestCheckEnvironment(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/info" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(uamclient.ServerInfo{Environment: "TEST"})
+	}))
+	defer srv.Close()
+	client, err := uamclient.NewClient(uamclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := t.Context()
+
+	for expected, wantErr := range map[string]bool{"": false, "test": false, "PROD": true} {
+		var diags diag.Diagnostics
+		checkEnvironment(ctx, client, expected, &diags)
+		if diags.HasError() != wantErr {
+			t.Errorf("checkEnvironment(%q) = %v, want error %t", expected, diags, wantErr)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// lookupByName returns the single item named name. The API filters by name
+// loosely, so items are matched exactly here again. A missing or ambiguous
+// name is reported as an error on attr; kind names the object in messages,
+// e.g. "Module" or "SoD Class".
+func lookupByName[T any](items []T, name string, nameOf, idOf func(T) string, attr path.Path, kind string) (T, diag.Diagnostics) {
+	return lookupByNameIn(items, "", name, nameOf, idOf, attr, kind)
+}
+
+// lookupModule returns the single module named name in application. Module
+// names are only unique within an application.
+func lookupModule(modules []uamclient.Module, application, name string, attr path.Path) (uamclient.Module, diag.Diagnostics) {
+	var inApplication []uamclient.Module
+	for _, m := range modules {
+		if m.ApplicationName == application {
+			inApplication = append(inApplication, m)
+		}
+	}
+	return lookupByNameIn(inApplication, fmt.Sprintf("application %q", application), name,
+		func(m uamclient.Module) string { return m.Name },
+		func(m uamclient.Module) string { return m.ID },
+		attr, "Module")
+}
+
+// lookupByNameIn is lookupByName for items that were filtered to scope, e.g.
+// `application "oska"`, which is then named in the messages. An empty scope
+// is omitted.
+func lookupByNameIn[T any](items []T, scope, name string, nameOf, idOf func(T) string, attr path.Path, kind string) (T, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var found []T
+	for _, item := range items {
+		if nameOf(item) == name {
+			found = append(found, item)
+		}
+	}
+	in := ""
+	if scope != "" {
+		in = " in " + scope
+	}
+
+	var zero T
+	switch len(found) {
+	case 0:
+		diags.AddAttributeError(
+			attr,
+			kind+" Not Found",
+			fmt.Sprintf("No %s named %q exists%s.", kind, name, in),
+		)
+		return zero, diags
+	case 1:
+		return found[0], diags
+	default:
+		ids := make([]string, 0, len(found))
+		for _, item := range found {
+			ids = append(ids, idOf(item))
+		}
+		diags.AddAttributeError(
+			attr,
+			"Ambiguous "+kind+" Name",
+			fmt.Sprintf("Found %d objects of type %s named %q%s (IDs %s). Use a more specific lookup or reference the ID directly.",
+				len(found), kind, name, in, strings.Join(ids, ", ")),
+		)
+		return zero, diags
+	}
+}
+
+// nameMap builds the by_name map of a list data source. Of several items
+// with the same name, it holds the first one listed.
+type nameMap struct {
+	ids        map[string]types.String
+	duplicates []string
+}
+
+func newNameMap() *nameMap {
+	return &nameMap{ids: map[string]types.String{}}
+}
+
+// add maps name to id unless an earlier item has the same name.
+func (m *nameMap) add(name, id string) {
+	if _, ok := m.ids[name]; ok {
+		m.duplicates = append(m.duplicates, name)
+		return
+	}
+	m.ids[name] = types.StringValue(id)
+}
+
+// warnDuplicates adds a warning on by_name if several items have the same
+// name. kind names the items in the summary, e.g. "Shop", and plural in the
+// detail, e.g. "shops"; alternatives says what tells them apart.
+func (m *nameMap) warnDuplicates(diags *diag.Diagnostics, kind, plural, alternatives string) {
+	if len(m.duplicates) == 0 {
+		return
+	}
+	slices.Sort(m.duplicates)
+	names := make([]string, 0, len(m.duplicates))
+	for _, name := range slices.Compact(m.duplicates) {
+		names = append(names, fmt.Sprintf("%q", name))
+	}
+	diags.AddAttributeWarning(
+		path.Root("by_name"),
+		"Duplicate uamoim "+kind+" Names",
+		fmt.Sprintf("Several %s are named %s. by_name holds the first one listed of each; "+
+			"use %s to tell them apart.", plural, strings.Join(names, ", "), alternatives),
+	)
+}
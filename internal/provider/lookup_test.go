@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+func TestLookupByName(t *testing.T) {
+	groups := []uamclient.Group{
+		{ID: "g-1", Name: "App.Application.PROD.oska.Leser"},
+		{ID: "g-2", Name: "App.Application.PROD.oska.Leser.Extern"},
+		{ID: "g-3", Name: "XZ41234"},
+		{ID: "g-4", Name: "XZ41234"},
+	}
+	nameOf := func(g uamclient.Group) string { return g.Name }
+	idOf := func(g uamclient.Group) string { return g.ID }
+	attr := path.Root("group_name")
+
+	group, diags := lookupByName(groups, "App.Application.PROD.oska.Leser", nameOf, idOf, attr, "Group")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if group.ID != "g-1" {
+		t.Errorf("got group %q, want g-1", group.ID)
+	}
+
+	tests := map[string]string{
+		"missing":   "Group Not Found",
+		"XZ41234":   "Ambiguous Group Name",
+		"App.Other": "Group Not Found",
+	}
+	for name, summary := range tests {
+		_, diags := lookupByName(groups, name, nameOf, idOf, attr, "Group")
+		if diags.ErrorsCount() != 1 {
+			t.Fatalf("%s: got %d errors, want 1", name, diags.ErrorsCount())
+		}
+		d := diags.Errors()[0]
+		if d.Summary() != summary {
+			t.Errorf("%s: summary = %q, want %q", name, d.Summary(), summary)
+		}
+		withPath, ok := d.(interface{ Path() path.Path })
+		if !ok || !withPath.Path().Equal(attr) {
+			t.Errorf("%s: diagnostic is not attached to %s", name, attr)
+		}
+	}
+}
+
+func TestLookupModule(t *testing.T) {
+	modules := []uamclient.Module{
+		{ID: "m-1", ApplicationName: "Application", Name: "OSKA"},
+		{ID: "m-2", ApplicationName: "Other", Name: "OSKA"},
+		{ID: "m-3", ApplicationName: "Other", Name: "XZ41234"},
+		{ID: "m-4", ApplicationName: "Other", Name: "XZ41234"},
+	}
+	attr := path.Root("module_name")
+
+	for application, want := range map[string]string{"Application": "m-1", "Other": "m-2"} {
+		module, diags := lookupModule(modules, application, "OSKA", attr)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", application, diags)
+		}
+		if module.ID != want {
+			t.Errorf("%s: got module %q, want %q", application, module.ID, want)
+		}
+	}
+
+	tests := []struct {
+		application, name, summary, detail string
+	}{
+		{"Third", "OSKA", "Module Not Found", `No Module named "OSKA" exists in application "Third".`},
+		{"Application", "XZ41234", "Module Not Found", `No Module named "XZ41234" exists in application "Application".`},
+		{"Other", "XZ41234", "Ambiguous Module Name", `Found 2 objects of type Module named "XZ41234" in application "Other" (IDs m-3, m-4). ` +
+			"Use a more specific lookup or reference the ID directly."},
+	}
+	for _, tt := range tests {
+		_, diags := lookupModule(modules, tt.application, tt.name, attr)
+		if diags.ErrorsCount() != 1 {
+			t.Fatalf("%s/%s: got %d errors, want 1", tt.application, tt.name, diags.ErrorsCount())
+		}
+		d := diags.Errors()[0]
+		if d.Summary() != tt.summary || d.Detail() != tt.detail {
+			t.Errorf("%s/%s: got %q: %q, want %q: %q", tt.application, tt.name, d.Summary(), d.Detail(), tt.summary, tt.detail)
+		}
+	}
+}
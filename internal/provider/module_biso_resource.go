@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &moduleBISOResource{}
+	_ resource.ResourceWithConfigure  = &moduleBISOResource{}
+	_ resource.ResourceWithModifyPlan = &moduleBISOResource{}
+)
+
+// NewModuleBISOResource is a helper function to simplify the provider implementation.
+func NewModuleBISOResource() resource.Resource {
+	return &moduleBISOResource{}
+}
+
+// moduleBISOResource is the resource implementation.
+type moduleBISOResource struct {
+	client             *uamclient.Client
+	defaultApplication string
+	changeReference    string
+}
+
+// moduleBISOResourceModel maps the resource schema data.
+type moduleBISOResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	ApplicationName types.String   `tfsdk:"application_name"`
+	ModuleID        types.String   `tfsdk:"module_id"`
+	BISOID          types.String   `tfsdk:"biso_id"`
+	Reason          types.String   `tfsdk:"reason"`
+	ChangeReference types.String   `tfsdk:"change_reference"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// moduleBISOAPIFields maps API request fields to the attributes they come from.
+var moduleBISOAPIFields = rootPaths("application_name", "module_id", "biso_id", "reason")
+
+func (m *moduleBISOResourceModel) fromAPI(biso *uamclient.ModuleBISO) {
+	m.ID = types.StringValue(biso.ModuleID + "/" + biso.BISOID)
+	m.ApplicationName = types.StringValue(biso.ApplicationName)
+	m.ModuleID = types.StringValue(biso.ModuleID)
+	m.BISOID = types.StringValue(biso.BISOID)
+	m.Reason = types.StringValue(biso.Reason)
+}
+
+func (r *moduleBISOResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = data.Client
+	r.defaultApplication = data.DefaultApplication
+	r.changeReference = data.ChangeReference
+}
+
+// Metadata returns the resource type name.
+func (r *moduleBISOResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_module_biso"
+}
+
+// Schema defines the schema for the resource.
+func (r *moduleBISOResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assigns a BISO group to a UAM/OIM module.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the assignment, <module_id>/<biso_id>.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_name": applicationNameAttribute("The name of the application the module belongs to. Changing it forces a new assignment."),
+			"module_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the module.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"biso_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the BISO group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"reason": schema.StringAttribute{
+				Required:    true,
+				Description: "The reason recorded for the assignment.",
+			},
+			"change_reference": changeReferenceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ModifyPlan plans the default application and change reference of the
+// provider if application_name or change_reference is omitted.
+func (r *moduleBISOResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planApplicationName(ctx, r.defaultApplication, req, resp)
+	planChangeReference(ctx, r.changeReference, req, resp)
+}
+
+// Create assigns the BISO and sets the initial Terraform state.
+func (r *moduleBISOResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan moduleBISOResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Assigning uamoim Module BISO") {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = uamclient.WithChangeReference(ctx, plan.ChangeReference.ValueString())
+	ctx = uamclient.WithResource(ctx, "uamoim_module_biso", plan.ID.ValueString())
+
+	biso, err := r.assign(ctx, plan)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Assigning uamoim Module BISO",
+			"Could not assign BISO "+plan.BISOID.ValueString()+" to module "+plan.ModuleID.ValueString(),
+			err, moduleBISOAPIFields,
+		)
+		return
+	}
+
+	plan.fromAPI(biso)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data. A BISO that was
+// removed from the module outside of Terraform is removed from the state, so
+// the next plan assigns it again.
+func (r *moduleBISOResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state moduleBISOResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	biso, err := r.client.ModuleBISOs.Get(ctx, state.ModuleID.ValueString(), state.BISOID.ValueString())
+	if uamclient.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Reading uamoim Module BISO",
+			"Could not read BISO "+state.BISOID.ValueString()+" of module "+state.ModuleID.ValueString(),
+			err, nil,
+		)
+		return
+	}
+
+	state.fromAPI(biso)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update changes the reason of the assignment.
+func (r *moduleBISOResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan moduleBISOResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Updating uamoim Module BISO") {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = uamclient.WithChangeReference(ctx, plan.ChangeReference.ValueString())
+	ctx = uamclient.WithResource(ctx, "uamoim_module_biso", plan.ID.ValueString())
+
+	biso, err := r.assign(ctx, plan)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Updating uamoim Module BISO",
+			"Could not update BISO "+plan.BISOID.ValueString()+" of module "+plan.ModuleID.ValueString(),
+			err, moduleBISOAPIFields,
+		)
+		return
+	}
+
+	plan.fromAPI(biso)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the BISO from the module.
+func (r *moduleBISOResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state moduleBISOResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Removing uamoim Module BISO") {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = deleteChangeReference(ctx, r.changeReference, state.ChangeReference)
+	ctx = uamclient.WithResource(ctx, "uamoim_module_biso", state.ID.ValueString())
+
+	err := r.client.ModuleBISOs.Unassign(ctx, state.ModuleID.ValueString(), state.BISOID.ValueString())
+	if err != nil && !uamclient.IsNotFound(err) {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Removing uamoim Module BISO",
+			"Could not remove BISO "+state.BISOID.ValueString()+" from module "+state.ModuleID.ValueString(),
+			err, nil,
+		)
+	}
+}
+
+func (r *moduleBISOResource) assign(ctx context.Context, plan moduleBISOResourceModel) (*uamclient.ModuleBISO, error) {
+	return r.client.ModuleBISOs.Assign(ctx, plan.ModuleID.ValueString(), plan.BISOID.ValueString(), uamclient.ModuleBISOAssign{
+		ApplicationName: plan.ApplicationName.ValueString(),
+		Reason:          plan.Reason.ValueString(),
+	})
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// fakeModuleBISOs is an in-memory API of the BISOs assigned to modules.
+type fakeModuleBISOs struct {
+	t       *testing.T
+	mu      sync.Mutex
+	bisos   map[string]uamclient.ModuleBISO
+	assigns int
+}
+
+func newFakeModuleBISOs(t *testing.T) *fakeModuleBISOs {
+	return &fakeModuleBISOs{t: t, bisos: map[string]uamclient.ModuleBISO{}}
+}
+
+func (f *fakeModuleBISOs) handler() http.Handler {
+	t := f.t
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/v1/modules/{id}/bisos/{biso}", func(w http.ResponseWriter, r *http.Request) {
+		var in uamclient.ModuleBISOAssign
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.assigns++
+		b := uamclient.ModuleBISO{
+			ApplicationName: in.ApplicationName,
+			ModuleID:        r.PathValue("id"),
+			BISOID:          r.PathValue("biso"),
+			Reason:          in.Reason,
+		}
+		f.bisos[b.ModuleID+"/"+b.BISOID] = b
+		writeTestJSON(t, w, http.StatusOK, b)
+	})
+	mux.HandleFunc("GET /api/v1/modules/{id}/bisos/{biso}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		b, ok := f.bisos[r.PathValue("id")+"/"+r.PathValue("biso")]
+		if !ok {
+			writeTestJSON(t, w, http.StatusNotFound, map[string]string{"message": "BISO not assigned"})
+			return
+		}
+		writeTestJSON(t, w, http.StatusOK, b)
+	})
+	mux.HandleFunc("DELETE /api/v1/modules/{id}/bisos/{biso}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		key := r.PathValue("id") + "/" + r.PathValue("biso")
+		if _, ok := f.bisos[key]; !ok {
+			writeTestJSON(t, w, http.StatusNotFound, map[string]string{"message": "BISO not assigned"})
+			return
+		}
+		delete(f.bisos, key)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func (f *fakeModuleBISOs) get(key string) (uamclient.ModuleBISO, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.bisos[key]
+	return b, ok
+}
+
+// remove unassigns a BISO as if it was done in OIM.
+func (f *fakeModuleBISOs) remove(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.bisos, key)
+}
+
+func TestModuleBISOResourceLifecycle(t *testing.T) {
+	api := newFakeModuleBISOs(t)
+	r := &moduleBISOResource{}
+	s := configureResource(t, r, newTestProviderData(t, api.handler()))
+	ctx := t.Context()
+
+	// Create.
+	plan := moduleBISOResourceModel{
+		ID:              types.StringUnknown(),
+		ApplicationName: types.StringValue("Application"),
+		ModuleID:        types.StringValue("m-1"),
+		BISOID:          types.StringValue("XZ41234"),
+		Reason:          types.StringValue("Owner of the shop data"),
+		ChangeReference: types.StringNull(),
+		Timeouts:        nullTimeouts(),
+	}
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	r.Create(ctx, resource.CreateRequest{Plan: testPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state moduleBISOResourceModel
+	getState(t, createResp.State, &state)
+	if state.ID.ValueString() != "m-1/XZ41234" {
+		t.Fatalf("id = %s, want m-1/XZ41234", state.ID)
+	}
+	if b, _ := api.get("m-1/XZ41234"); b.ApplicationName != "Application" || b.Reason != "Owner of the shop data" {
+		t.Errorf("assigned BISO %+v", b)
+	}
+
+	// Update the reason in place.
+	plan = state
+	plan.Reason = types.StringValue("Deputy owner")
+	updateResp := resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: testPlan(t, s, &plan), State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	getState(t, updateResp.State, &state)
+	if state.ID.ValueString() != "m-1/XZ41234" || state.Reason.ValueString() != "Deputy owner" {
+		t.Errorf("updated state %+v", state)
+	}
+	if b, _ := api.get("m-1/XZ41234"); api.assigns != 2 || b.Reason != "Deputy owner" {
+		t.Errorf("%d assignments, BISO %+v", api.assigns, b)
+	}
+
+	// Delete.
+	deleteResp := resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if _, ok := api.get("m-1/XZ41234"); ok {
+		t.Error("BISO still assigned after Delete")
+	}
+}
+
+// A BISO removed from the module in OIM is drift: Read removes it from the
+// state, so that the next plan assigns it again.
+func TestModuleBISOResourceReadRemovedInOIM(t *testing.T) {
+	api := newFakeModuleBISOs(t)
+	api.bisos["m-1/XZ41234"] = uamclient.ModuleBISO{
+		ApplicationName: "Application", ModuleID: "m-1", BISOID: "XZ41234", Reason: "Owner of the shop data",
+	}
+	r := &moduleBISOResource{}
+	s := configureResource(t, r, newTestProviderData(t, api.handler()))
+	ctx := t.Context()
+
+	state := testState(t, s, &moduleBISOResourceModel{
+		ID:              types.StringValue("m-1/XZ41234"),
+		ApplicationName: types.StringValue("Application"),
+		ModuleID:        types.StringValue("m-1"),
+		BISOID:          types.StringValue("XZ41234"),
+		Reason:          types.StringValue("Owner of the shop data"),
+		ChangeReference: types.StringNull(),
+		Timeouts:        nullTimeouts(),
+	})
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		t.Fatalf("Read of an assigned BISO: %v, removed = %t", resp.Diagnostics, resp.State.Raw.IsNull())
+	}
+
+	api.remove("m-1/XZ41234")
+	resp = resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("a BISO removed in OIM was not removed from the state")
+	}
+}
+
+// The module, BISO and application of an assignment identify it, so changing
+// them replaces it. The reason is updated in place.
+func TestModuleBISOResourceRequiresReplace(t *testing.T) {
+	r := &moduleBISOResource{}
+	s := configureResource(t, r, &uamoimProviderData{})
+
+	tests := map[string]bool{
+		"application_name": true,
+		"module_id":        true,
+		"biso_id":          true,
+		"reason":           false,
+	}
+	for name, want := range tests {
+		if got := requiresReplace(t, s, name, "old", "new"); got != want {
+			t.Errorf("%s: requires replace = %t, want %t", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &moduleByNameDataSource{}
+	_ datasource.DataSourceWithConfigure = &moduleByNameDataSource{}
+)
+
+type moduleByNameDataSourceModel struct {
+	ApplicationName types.String `tfsdk:"application_name"`
+	ModuleName      types.String `tfsdk:"module_name"`
+	ID              types.String `tfsdk:"id"`
+	Description     types.String `tfsdk:"description"`
+}
+
+func NewModuleByNameDataSource() datasource.DataSource {
+	return &moduleByNameDataSource{}
+}
+
+type moduleByNameDataSource struct {
+	client             *uamclient.Client
+	defaultApplication string
+}
+
+func (d *moduleByNameDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = data.Client
+	d.defaultApplication = data.DefaultApplication
+}
+
+func (d *moduleByNameDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_module_by_name"
+}
+
+func (d *moduleByNameDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single module of an application by its name.",
+		Attributes: map[string]schema.Attribute{
+			"application_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the application the module belongs to. Defaults to the default_application of the provider.",
+			},
+			"module_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The exact name of the module.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the module.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "The description of the module.",
+			},
+		},
+	}
+}
+
+func (d *moduleByNameDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state moduleByNameDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	application, diags := effectiveApplication(state.ApplicationName, d.defaultApplication)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ApplicationName = application
+
+	modules, err := d.client.Modules.List(ctx, &uamclient.ModuleListOptions{
+		ApplicationName: state.ApplicationName.ValueString(),
+		Name:            state.ModuleName.ValueString(),
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Unable to Read uamoim Modules",
+			"Could not list modules named "+state.ModuleName.ValueString(),
+			err, nil,
+		)
+		return
+	}
+
+	module, diags := lookupModule(modules, state.ApplicationName.ValueString(), state.ModuleName.ValueString(),
+		path.Root("module_name"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(module.ID)
+	state.Description = types.StringValue(module.Description)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
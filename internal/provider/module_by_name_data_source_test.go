@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// sameNameModules serves two modules of the same name in different
+// applications, regardless of the filters, as the API matches loosely.
+func sameNameModules(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/modules", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(t, w, http.StatusOK, map[string]any{"items": []uamclient.Module{
+			{ID: "m-1", ApplicationName: "Application", Name: "OSKA", Description: "first"},
+			{ID: "m-2", ApplicationName: "Other", Name: "OSKA", Description: "second"},
+		}})
+	})
+	return mux
+}
+
+func TestModuleByNameDataSourceRead(t *testing.T) {
+	d := NewModuleByNameDataSource()
+	s := configureDataSource(t, d, newTestProviderData(t, sameNameModules(t)))
+
+	config := testConfig(t, s, &moduleByNameDataSourceModel{
+		ApplicationName: types.StringValue("Other"),
+		ModuleName:      types.StringValue("OSKA"),
+		ID:              types.StringNull(),
+		Description:     types.StringNull(),
+	})
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	d.Read(t.Context(), datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+
+	var got moduleByNameDataSourceModel
+	getState(t, resp.State, &got)
+	if got.ID.ValueString() != "m-2" || got.Description.ValueString() != "second" {
+		t.Errorf("got module %s (%s), want m-2 (second)", got.ID, got.Description)
+	}
+}
@@ -0,0 +1,309 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &moduleResource{}
+	_ resource.ResourceWithConfigure   = &moduleResource{}
+	_ resource.ResourceWithModifyPlan  = &moduleResource{}
+	_ resource.ResourceWithImportState = &moduleResource{}
+)
+
+// NewModuleResource is a helper function to simplify the provider implementation.
+func NewModuleResource() resource.Resource {
+	return &moduleResource{}
+}
+
+// moduleResource is the resource implementation.
+type moduleResource struct {
+	client             *uamclient.Client
+	defaultApplication string
+	changeReference    string
+}
+
+// moduleResourceModel maps the resource schema data.
+type moduleResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	ApplicationName types.String   `tfsdk:"application_name"`
+	ModuleName      types.String   `tfsdk:"module_name"`
+	Description     types.String   `tfsdk:"description"`
+	ChangeReference types.String   `tfsdk:"change_reference"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// moduleAPIFields maps API request fields to the attributes they come from.
+var moduleAPIFields = map[string]path.Path{
+	"application_name": path.Root("application_name"),
+	"name":             path.Root("module_name"),
+	"description":      path.Root("description"),
+}
+
+func (m *moduleResourceModel) fromAPI(module *uamclient.Module) {
+	m.ID = types.StringValue(module.ID)
+	m.ApplicationName = types.StringValue(module.ApplicationName)
+	m.ModuleName = types.StringValue(module.Name)
+	m.Description = types.StringValue(module.Description)
+}
+
+func (r *moduleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = data.Client
+	r.defaultApplication = data.DefaultApplication
+	r.changeReference = data.ChangeReference
+}
+
+// Metadata returns the resource type name.
+func (r *moduleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_module"
+}
+
+// Schema defines the schema for the resource.
+func (r *moduleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a module of a UAM/OIM application.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the module.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_name": applicationNameAttribute("The name of the application the module belongs to. Changing it forces a new module."),
+			"module_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the module.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "The description of the module.",
+			},
+			"change_reference": changeReferenceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ModifyPlan plans the default application and change reference of the
+// provider if application_name or change_reference is omitted.
+func (r *moduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planApplicationName(ctx, r.defaultApplication, req, resp)
+	planChangeReference(ctx, r.changeReference, req, resp)
+}
+
+// Create creates the module and sets the initial Terraform state.
+func (r *moduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan moduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Creating uamoim Module") {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = uamclient.WithChangeReference(ctx, plan.ChangeReference.ValueString())
+	ctx = uamclient.WithResource(ctx, "uamoim_module", plan.ID.ValueString())
+
+	module, err := r.client.Modules.Create(ctx, uamclient.ModuleCreate{
+		ApplicationName: plan.ApplicationName.ValueString(),
+		Name:            plan.ModuleName.ValueString(),
+		Description:     plan.Description.ValueStringPointer(),
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Creating uamoim Module",
+			"Could not create module "+plan.ModuleName.ValueString(),
+			err, moduleAPIFields,
+		)
+		return
+	}
+
+	plan.fromAPI(module)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data. A module that
+// was deleted outside of Terraform is removed from the state.
+func (r *moduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state moduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	module, err := r.client.Modules.Get(ctx, state.ID.ValueString())
+	if uamclient.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Reading uamoim Module",
+			"Could not read module ID "+state.ID.ValueString(),
+			err, nil,
+		)
+		return
+	}
+
+	state.fromAPI(module)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the module and sets the updated Terraform state on success.
+func (r *moduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan moduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Updating uamoim Module") {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = uamclient.WithChangeReference(ctx, plan.ChangeReference.ValueString())
+	ctx = uamclient.WithResource(ctx, "uamoim_module", plan.ID.ValueString())
+
+	module, err := r.client.Modules.Update(ctx, plan.ID.ValueString(), uamclient.ModuleUpdate{
+		Name:        plan.ModuleName.ValueString(),
+		Description: plan.Description.ValueStringPointer(),
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Updating uamoim Module",
+			"Could not update module ID "+plan.ID.ValueString(),
+			err, moduleAPIFields,
+		)
+		return
+	}
+
+	plan.fromAPI(module)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the module and removes the Terraform state on success.
+func (r *moduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state moduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Deleting uamoim Module") {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = deleteChangeReference(ctx, r.changeReference, state.ChangeReference)
+	ctx = uamclient.WithResource(ctx, "uamoim_module", state.ID.ValueString())
+
+	err := r.client.Modules.Delete(ctx, state.ID.ValueString())
+	if err != nil && !uamclient.IsNotFound(err) {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Deleting uamoim Module",
+			"Could not delete module ID "+state.ID.ValueString(),
+			err, nil,
+		)
+	}
+}
+
+// ImportState imports a module either by its ID or by
+// "<application_name>/<module_name>".
+func (r *moduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	application, name, found := strings.Cut(req.ID, "/")
+	if !found {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+	if application == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <id> or <application_name>/<module_name>, got: %q", req.ID),
+		)
+		return
+	}
+
+	modules, err := r.client.Modules.List(ctx, &uamclient.ModuleListOptions{
+		ApplicationName: application,
+		Name:            name,
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Reading uamoim Modules",
+			"Could not look up module "+req.ID,
+			err, nil,
+		)
+		return
+	}
+	module, diags := lookupModule(modules, application, name, path.Root("id"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), module.ID)...)
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// fakeModules is an in-memory module API.
+type fakeModules struct {
+	t       *testing.T
+	mu      sync.Mutex
+	modules map[string]uamclient.Module
+	nextID  int
+}
+
+func newFakeModules(t *testing.T) *fakeModules {
+	return &fakeModules{t: t, modules: map[string]uamclient.Module{}}
+}
+
+func (f *fakeModules) handler() http.Handler {
+	t := f.t
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/modules", func(w http.ResponseWriter, r *http.Request) {
+		var in uamclient.ModuleCreate
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.nextID++
+		m := uamclient.Module{ID: fmt.Sprintf("m-%d", f.nextID), ApplicationName: in.ApplicationName, Name: in.Name}
+		if in.Description != nil {
+			m.Description = *in.Description
+		}
+		f.modules[m.ID] = m
+		writeTestJSON(t, w, http.StatusCreated, m)
+	})
+	mux.HandleFunc("GET /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		m, ok := f.modules[r.PathValue("id")]
+		if !ok {
+			writeTestJSON(t, w, http.StatusNotFound, map[string]string{"message": "module not found"})
+			return
+		}
+		writeTestJSON(t, w, http.StatusOK, m)
+	})
+	mux.HandleFunc("PUT /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var in uamclient.ModuleUpdate
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		m, ok := f.modules[r.PathValue("id")]
+		if !ok {
+			writeTestJSON(t, w, http.StatusNotFound, map[string]string{"message": "module not found"})
+			return
+		}
+		m.Name = in.Name
+		if in.Description != nil {
+			m.Description = *in.Description
+		}
+		f.modules[m.ID] = m
+		writeTestJSON(t, w, http.StatusOK, m)
+	})
+	mux.HandleFunc("DELETE /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.modules[r.PathValue("id")]; !ok {
+			writeTestJSON(t, w, http.StatusNotFound, map[string]string{"message": "module not found"})
+			return
+		}
+		delete(f.modules, r.PathValue("id"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func (f *fakeModules) get(id string) (uamclient.Module, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, ok := f.modules[id]
+	return m, ok
+}
+
+func (f *fakeModules) set(m uamclient.Module) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.modules[m.ID] = m
+}
+
+func TestModuleResourceLifecycle(t *testing.T) {
+	api := newFakeModules(t)
+	r := &moduleResource{}
+	s := configureResource(t, r, newTestProviderData(t, api.handler()))
+	ctx := t.Context()
+
+	// Create.
+	plan := moduleResourceModel{
+		ID:              types.StringUnknown(),
+		ApplicationName: types.StringValue("Application"),
+		ModuleName:      types.StringValue("OSKA"),
+		Description:     types.StringValue("Online shop"),
+		ChangeReference: types.StringNull(),
+		Timeouts:        nullTimeouts(),
+	}
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	r.Create(ctx, resource.CreateRequest{Plan: testPlan(t, s, &plan)}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state moduleResourceModel
+	getState(t, createResp.State, &state)
+	if state.ID.ValueString() != "m-1" {
+		t.Fatalf("id = %s, want m-1", state.ID)
+	}
+	if m, _ := api.get("m-1"); m.ApplicationName != "Application" || m.Name != "OSKA" || m.Description != "Online shop" {
+		t.Errorf("created module %+v", m)
+	}
+
+	// Read picks up a change made outside of Terraform.
+	api.set(uamclient.Module{ID: "m-1", ApplicationName: "Application", Name: "OSKA", Description: "changed"})
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	getState(t, readResp.State, &state)
+	if state.Description.ValueString() != "changed" {
+		t.Errorf("description = %s, want changed", state.Description)
+	}
+
+	// Update in place, keeping the ID from the state.
+	plan = state
+	plan.ModuleName = types.StringValue("OSKA2")
+	plan.Description = types.StringValue("Online shop")
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: testPlan(t, s, &plan), State: readResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	getState(t, updateResp.State, &state)
+	if state.ID.ValueString() != "m-1" || state.ModuleName.ValueString() != "OSKA2" {
+		t.Errorf("updated state %+v", state)
+	}
+	if m, _ := api.get("m-1"); m.Name != "OSKA2" || m.Description != "Online shop" {
+		t.Errorf("updated module %+v", m)
+	}
+
+	// Delete.
+	deleteResp := resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", deleteResp.Diagnostics)
+	}
+	if _, ok := api.get("m-1"); ok {
+		t.Error("module still exists after Delete")
+	}
+}
+
+func TestModuleResourceReadNotFound(t *testing.T) {
+	r := &moduleResource{}
+	s := configureResource(t, r, newTestProviderData(t, newFakeModules(t).handler()))
+
+	state := testState(t, s, &moduleResourceModel{
+		ID:              types.StringValue("m-1"),
+		ApplicationName: types.StringValue("Application"),
+		ModuleName:      types.StringValue("OSKA"),
+		Description:     types.StringValue(""),
+		ChangeReference: types.StringNull(),
+		Timeouts:        nullTimeouts(),
+	})
+	resp := resource.ReadResponse{State: state}
+	r.Read(t.Context(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("a deleted module was not removed from the state")
+	}
+}
+
+func TestModuleResourceImport(t *testing.T) {
+	r := &moduleResource{}
+	s := configureResource(t, r, newTestProviderData(t, sameNameModules(t)))
+
+	tests := map[string]string{
+		"m-7":              "m-7",
+		"Application/OSKA": "m-1",
+		"Other/OSKA":       "m-2",
+	}
+	for id, want := range tests {
+		resp := resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+		r.ImportState(t.Context(), resource.ImportStateRequest{ID: id}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("ImportState(%s): %v", id, resp.Diagnostics)
+		}
+		var got types.String
+		resp.State.GetAttribute(t.Context(), path.Root("id"), &got)
+		if got.ValueString() != want {
+			t.Errorf("ImportState(%s): id = %s, want %s", id, got, want)
+		}
+	}
+
+	for _, id := range []string{"Third/OSKA", "/OSKA", "Application/"} {
+		resp := resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+		r.ImportState(t.Context(), resource.ImportStateRequest{ID: id}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("ImportState(%s): expected an error", id)
+		}
+	}
+}
@@ -6,12 +6,16 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -28,7 +32,8 @@ func NewOrderResource() resource.Resource {
 
 // orderResource is the resource implementation.
 type orderResource struct {
-	client *hashicups.Client
+	client          *uamclient.Client
+	changeReference string
 }
 
 func (r *orderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -36,15 +41,17 @@ func (r *orderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanR
 		return
 	}
 
+	planChangeReference(ctx, r.changeReference, req, resp)
+
 	var plan orderResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() || len(plan.Items) == 0 {
 		return
 	}
 
-	coffees, err := r.client.GetCoffees()
+	coffees, err := r.client.Coffees.List(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Error Reading HashiCups Coffees", err.Error())
+		addAPIError(&resp.Diagnostics, "Error Reading HashiCups Coffees", "Could not list coffees", err, nil)
 		return
 	}
 
@@ -94,12 +101,13 @@ func (r *orderResource) Configure(ctx context.Context, req resource.ConfigureReq
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*hashicups.Client)
+	data, ok := req.ProviderData.(*uamoimProviderData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *hashicups.Client")
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *provider.uamoimProviderData")
 		return
 	}
-	r.client = client
+	r.client = data.Client
+	r.changeReference = data.ChangeReference
 }
 
 // Metadata returns the resource type name.
@@ -108,11 +116,14 @@ func (r *orderResource) Metadata(_ context.Context, req resource.MetadataRequest
 }
 
 // Schema defines the schema for the resource.
-func (r *orderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *orderResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Computed: true,
@@ -153,15 +164,26 @@ func (r *orderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+			"change_reference": changeReferenceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
 
 // orderResourceModel maps the resource schema data.
 type orderResourceModel struct {
-	ID          types.String     `tfsdk:"id"`
-	Items       []orderItemModel `tfsdk:"items"`
-	LastUpdated types.String     `tfsdk:"last_updated"`
+	ID              types.String     `tfsdk:"id"`
+	Items           []orderItemModel `tfsdk:"items"`
+	LastUpdated     types.String     `tfsdk:"last_updated"`
+	ChangeReference types.String     `tfsdk:"change_reference"`
+	Timeouts        timeouts.Value   `tfsdk:"timeouts"`
+}
+
+// orderAPIFields maps API request fields to the attributes they come from.
+var orderAPIFields = map[string]path.Path{
+	"items": path.Root("items"),
 }
 
 // orderItemModel maps order item data.
@@ -185,9 +207,9 @@ type orderItemCoffeeModel struct {
 // The create method follows these steps:
 // 1. Checks whether the API Client is configured. If not, the resource responds with an error.
 // 2. Retrieves values from the plan. The function will attempt to retrieve values from the plan and convert it to an orderResourceModel.
-// 3. Generates an API request body from the plan values. The function loops through each plan item and maps it to a hashicups.OrderItem. This is what the API client needs to create a new order.
-// 4. Creates a new order. The function invokes the API client's CreateOrder method.
-// 5. Maps response body to resource schema attributes. After the function creates an order, it maps the hashicups.Order response to []OrderItem so the provider can update the Terraform state.
+// 3. Generates an API request body from the plan values. The function loops through each plan item and maps it to a uamclient.OrderItem. This is what the API client needs to create a new order.
+// 4. Creates a new order. The function invokes the API client's Orders.Create method.
+// 5. Maps response body to resource schema attributes. After the function creates an order, it maps the uamclient.Order response to []OrderItem so the provider can update the Terraform state.
 // 6. Sets Terraform's state with the new order's details.
 func (r *orderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -197,22 +219,39 @@ func (r *orderResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error creating order") {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = uamclient.WithChangeReference(ctx, plan.ChangeReference.ValueString())
+	ctx = uamclient.WithResource(ctx, "uamoim_order", plan.ID.ValueString())
+
 	// Generate API request body from plan
-	var items []hashicups.OrderItem
+	var items []uamclient.OrderItem
 	for _, item := range plan.Items {
-		items = append(items, hashicups.OrderItem{
-			Coffee: hashicups.Coffee{
+		items = append(items, uamclient.OrderItem{
+			Coffee: uamclient.Coffee{
 				ID: int(item.Coffee.ID.ValueInt64()),
 			},
 			Quantity: int(item.Quantity.ValueInt64()),
 		})
 	}
 	// Create new order
-	order, err := r.client.CreateOrder(items)
+	order, err := r.client.Orders.Create(ctx, items)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error creating order",
-			"Could not create order, unexpected error: "+err.Error(),
+			"Could not create order",
+			err, orderAPIFields,
 		)
 		return
 	}
@@ -246,8 +285,8 @@ func (r *orderResource) Create(ctx context.Context, req resource.CreateRequest,
 // The read function follows these steps:
 // 1. Gets the current state. If it is unable to, the provider responds with an error.
 // 2. Retrieves the order ID from Terraform's state.
-// 3. Retrieves the order details from the client. The function invokes the API client's GetOrder method with the order ID.
-// 4. Maps the response body to resource schema attributes. After the function retrieves the order, it maps the hashicups.Order response to []OrderItem so the provider can update the Terraform state.
+// 3. Retrieves the order details from the client. The function invokes the API client's Orders.Get method with the order ID. If the order no longer exists, the resource is removed from the state.
+// 4. Maps the response body to resource schema attributes. After the function retrieves the order, it maps the uamclient.Order response to []OrderItem so the provider can update the Terraform state.
 // 5. Set Terraform's state with the order's details.
 func (r *orderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Get current state
@@ -258,12 +297,27 @@ func (r *orderResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Get refreshed order value from HashiCups
-	order, err := r.client.GetOrder(state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Get refreshed order value from HashiCups. An order deleted outside of
+	// Terraform is removed from the state so that it is planned for creation.
+	order, err := r.client.Orders.Get(ctx, state.ID.ValueString())
+	if uamclient.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Reading HashiCups Order",
-			"Could not read HashiCups order ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read HashiCups order ID "+state.ID.ValueString(),
+			err, nil,
 		)
 		return
 	}
@@ -293,9 +347,131 @@ func (r *orderResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
+// The update function follows these steps:
+// 1. Retrieves values from the plan. The function will attempt to retrieve values from the plan and convert it to an orderResourceModel. The order ID is taken from the state, which always holds it.
+// 2. Generates an API request body from the plan values, the same way Create does.
+// 3. Updates the order. The function invokes the API client's Orders.Update method with the order ID.
+// 4. Fetches the updated order. The update response is not guaranteed to contain all coffee details, so the order is read again.
+// 5. Maps the response body to resource schema attributes and sets last_updated.
+// 6. Sets Terraform's state with the updated order's details.
 func (r *orderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan orderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &plan.ID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Updating HashiCups Order") {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = uamclient.WithChangeReference(ctx, plan.ChangeReference.ValueString())
+	ctx = uamclient.WithResource(ctx, "uamoim_order", plan.ID.ValueString())
+
+	// Generate API request body from plan
+	var items []uamclient.OrderItem
+	for _, item := range plan.Items {
+		items = append(items, uamclient.OrderItem{
+			Coffee: uamclient.Coffee{
+				ID: int(item.Coffee.ID.ValueInt64()),
+			},
+			Quantity: int(item.Quantity.ValueInt64()),
+		})
+	}
+
+	// Update existing order
+	_, err := r.client.Orders.Update(ctx, plan.ID.ValueString(), items)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Updating HashiCups Order",
+			"Could not update order",
+			err, orderAPIFields,
+		)
+		return
+	}
+
+	// Fetch updated items from Orders.Get as Orders.Update items are not
+	// populated.
+	order, err := r.client.Orders.Get(ctx, plan.ID.ValueString())
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Reading HashiCups Order",
+			"Could not read HashiCups order ID "+plan.ID.ValueString(),
+			err, nil,
+		)
+		return
+	}
+
+	// Update resource state with updated items and timestamp
+	plan.Items = []orderItemModel{}
+	for _, item := range order.Items {
+		plan.Items = append(plan.Items, orderItemModel{
+			Coffee: orderItemCoffeeModel{
+				ID:          types.Int64Value(int64(item.Coffee.ID)),
+				Name:        types.StringValue(item.Coffee.Name),
+				Teaser:      types.StringValue(item.Coffee.Teaser),
+				Description: types.StringValue(item.Coffee.Description),
+				Price:       types.Float64Value(item.Coffee.Price),
+				Image:       types.StringValue(item.Coffee.Image),
+			},
+			Quantity: types.Int64Value(int64(item.Quantity)),
+		})
+	}
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
+// An order that is already gone on the server is treated as deleted.
 func (r *orderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state orderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Deleting HashiCups Order") {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = deleteChangeReference(ctx, r.changeReference, state.ChangeReference)
+	ctx = uamclient.WithResource(ctx, "uamoim_order", state.ID.ValueString())
+
+	// Delete existing order
+	err := r.client.Orders.Delete(ctx, state.ID.ValueString())
+	if err != nil && !uamclient.IsNotFound(err) {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Deleting HashiCups Order",
+			"Could not delete order",
+			err, nil,
+		)
+		return
+	}
 }
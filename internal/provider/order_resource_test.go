@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+var testCoffees = map[int]uamclient.Coffee{
+	1: {ID: 1, Name: "Packer Spiced Latte", Teaser: "Packed with goodness", Description: "", Price: 350, Image: "/packer.png"},
+	2: {ID: 2, Name: "Vaulatte", Teaser: "Nothing gives you a safe and secure feeling like a Vaulatte", Price: 200, Image: "/vault.png"},
+}
+
+func TestOrderResourceUpdate(t *testing.T) {
+	var updated []uamclient.OrderItem
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if id := r.PathValue("id"); id != "7" {
+			t.Errorf("PUT order %q, want 7", id)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		// The update response does not contain the coffee details.
+		writeTestJSON(t, w, http.StatusOK, uamclient.Order{ID: 7, Items: updated})
+	})
+	mux.HandleFunc("GET /orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		order := uamclient.Order{ID: 7}
+		for _, item := range updated {
+			order.Items = append(order.Items, uamclient.OrderItem{Coffee: testCoffees[item.Coffee.ID], Quantity: item.Quantity})
+		}
+		writeTestJSON(t, w, http.StatusOK, order)
+	})
+	r := NewOrderResource()
+	s := configureResource(t, r, newTestProviderData(t, mux))
+	ctx := t.Context()
+
+	state := orderResourceModel{
+		ID:          types.StringValue("7"),
+		LastUpdated: types.StringValue("Monday, 01-Jan-26 00:00:00 UTC"),
+		Items: []orderItemModel{{
+			Coffee:   testOrderCoffee(testCoffees[1]),
+			Quantity: types.Int64Value(1),
+		}},
+		ChangeReference: types.StringNull(),
+		Timeouts:        nullTimeouts(),
+	}
+	// The plan of a changed order, with the computed attributes unknown.
+	plan := orderResourceModel{
+		ID:          types.StringUnknown(),
+		LastUpdated: types.StringUnknown(),
+		Items: []orderItemModel{{
+			Coffee: orderItemCoffeeModel{
+				ID:          types.Int64Value(2),
+				Name:        types.StringUnknown(),
+				Teaser:      types.StringUnknown(),
+				Description: types.StringUnknown(),
+				Price:       types.Float64Value(200),
+				Image:       types.StringUnknown(),
+			},
+			Quantity: types.Int64Value(3),
+		}},
+		ChangeReference: types.StringNull(),
+		Timeouts:        nullTimeouts(),
+	}
+
+	req := resource.UpdateRequest{State: testState(t, s, &state), Plan: testPlan(t, s, &plan)}
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	r.Update(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", resp.Diagnostics)
+	}
+
+	if len(updated) != 1 || updated[0].Coffee.ID != 2 || updated[0].Quantity != 3 {
+		t.Errorf("updated items = %+v", updated)
+	}
+	var got orderResourceModel
+	getState(t, resp.State, &got)
+	if got.ID.ValueString() != "7" {
+		t.Errorf("id = %s, want 7", got.ID)
+	}
+	if len(got.Items) != 1 || !got.Items[0].Coffee.Name.Equal(types.StringValue("Vaulatte")) ||
+		!got.Items[0].Coffee.Image.Equal(types.StringValue("/vault.png")) || got.Items[0].Quantity.ValueInt64() != 3 {
+		t.Errorf("items = %+v", got.Items)
+	}
+	if got.LastUpdated.IsUnknown() || got.LastUpdated.Equal(state.LastUpdated) {
+		t.Errorf("last_updated = %s, want a new timestamp", got.LastUpdated)
+	}
+}
+
+func TestOrderResourceDeleteNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(t, w, http.StatusNotFound, map[string]string{"message": "order not found"})
+	})
+	r := NewOrderResource()
+	s := configureResource(t, r, newTestProviderData(t, mux))
+
+	state := testState(t, s, &orderResourceModel{
+		ID:              types.StringValue("7"),
+		LastUpdated:     types.StringNull(),
+		Items:           []orderItemModel{{Coffee: testOrderCoffee(testCoffees[1]), Quantity: types.Int64Value(1)}},
+		ChangeReference: types.StringNull(),
+		Timeouts:        nullTimeouts(),
+	})
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(t.Context(), resource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("Delete of a missing order: %v", resp.Diagnostics)
+	}
+}
+
+func TestOrderResourceReadNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(t, w, http.StatusNotFound, map[string]string{"message": "order not found"})
+	})
+	r := NewOrderResource()
+	s := configureResource(t, r, newTestProviderData(t, mux))
+
+	state := testState(t, s, &orderResourceModel{
+		ID:              types.StringValue("7"),
+		LastUpdated:     types.StringNull(),
+		Items:           []orderItemModel{{Coffee: testOrderCoffee(testCoffees[1]), Quantity: types.Int64Value(1)}},
+		ChangeReference: types.StringNull(),
+		Timeouts:        nullTimeouts(),
+	})
+	resp := resource.ReadResponse{State: state}
+	r.Read(t.Context(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("a deleted order was not removed from the state")
+	}
+}
+
+func testOrderCoffee(c uamclient.Coffee) orderItemCoffeeModel {
+	return orderItemCoffeeModel{
+		ID:          types.Int64Value(int64(c.ID)),
+		Name:        types.StringValue(c.Name),
+		Teaser:      types.StringValue(c.Teaser),
+		Description: types.StringValue(c.Description),
+		Price:       types.Float64Value(c.Price),
+		Image:       types.StringValue(c.Image),
+	}
+}
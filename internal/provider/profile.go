@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultProfileFile is the profile file, relative to the home directory,
+// unless profile_file or UAMOIM_CONFIG_FILE say otherwise.
+const defaultProfileFile = ".config/uamoim/config.yaml"
+
+// profileFile is the content of the profile file:
+//
+//	profiles:
+//	  prod:
+//	    host: https://oim.example.com
+//	    oauth:
+//	      token_url: https://login.example.com/oauth2/token
+//	      client_id: terraform
+//	      client_secret: ...
+//	    ca_cert_file: /etc/pki/internal-ca.pem
+type profileFile struct {
+	Profiles map[string]profile `yaml:"profiles"`
+}
+
+// profile holds the connection settings of one OIM instance.
+type profile struct {
+	Host     string `yaml:"host"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+
+	OAuth *struct {
+		TokenURL     string   `yaml:"token_url"`
+		ClientID     string   `yaml:"client_id"`
+		ClientSecret string   `yaml:"client_secret"`
+		Scopes       []string `yaml:"scopes"`
+	} `yaml:"oauth"`
+
+	CACertFile         string `yaml:"ca_cert_file"`
+	CACertPEM          string `yaml:"ca_cert_pem"`
+	ClientCertFile     string `yaml:"client_cert_file"`
+	ClientKeyFile      string `yaml:"client_key_file"`
+	TLSServerName      string `yaml:"tls_server_name"`
+	InsecureSkipVerify *bool  `yaml:"insecure_skip_verify"`
+}
+
+// environment looks up the settings that are not configured in the provider
+// block: first in the UAMOIM_* environment variables, then in the selected
+// profile.
+type environment struct {
+	// profile holds the profile settings by the name of the matching
+	// environment variable.
+	profile map[string]string
+}
+
+// get returns the value of the environment variable name, or the matching
+// profile setting if it is not set.
+func (e environment) get(name string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return e.profileSetting(name)
+}
+
+// profileSetting returns the profile setting name, ignoring the environment
+// variable.
+func (e environment) profileSetting(name string) string {
+	return e.profile[name]
+}
+
+// value returns the value of v, or the setting name if v is not set.
+func (e environment) value(v types.String, name string) string {
+	if v.IsNull() || v.IsUnknown() {
+		return e.get(name)
+	}
+	return v.ValueString()
+}
+
+// loadEnvironment returns the environment with the named profile from file,
+// or without a profile if name is empty. An empty file means the default
+// profile file in the home directory.
+func loadEnvironment(name, file string) (environment, error) {
+	if name == "" {
+		return environment{}, nil
+	}
+	if file == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return environment{}, fmt.Errorf("locate profile file: %w", err)
+		}
+		file = filepath.Join(home, defaultProfileFile)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return environment{}, fmt.Errorf("read profile file: %w", err)
+	}
+	defer f.Close()
+
+	var pf profileFile
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&pf); err != nil && !errors.Is(err, io.EOF) {
+		return environment{}, fmt.Errorf("parse profile file %s: %w", file, err)
+	}
+	p, ok := pf.Profiles[name]
+	if !ok {
+		return environment{}, fmt.Errorf("profile %q not found in %s", name, file)
+	}
+	return environment{profile: p.settings()}, nil
+}
+
+// settings returns the settings of p by the name of the matching environment
+// variable.
+func (p profile) settings() map[string]string {
+	s := map[string]string{
+		"UAMOIM_HOST":             p.Host,
+		"UAMOIM_USERNAME":         p.Username,
+		"UAMOIM_PASSWORD":         p.Password,
+		"UAMOIM_CA_CERT_FILE":     p.CACertFile,
+		"UAMOIM_CA_CERT_PEM":      p.CACertPEM,
+		"UAMOIM_CLIENT_CERT_FILE": p.ClientCertFile,
+		"UAMOIM_CLIENT_KEY_FILE":  p.ClientKeyFile,
+		"UAMOIM_TLS_SERVER_NAME":  p.TLSServerName,
+	}
+	if p.OAuth != nil {
+		s["UAMOIM_TOKEN_URL"] = p.OAuth.TokenURL
+		s["UAMOIM_CLIENT_ID"] = p.OAuth.ClientID
+		s["UAMOIM_CLIENT_SECRET"] = p.OAuth.ClientSecret
+		s["UAMOIM_SCOPES"] = strings.Join(p.OAuth.Scopes, " ")
+	}
+	if p.InsecureSkipVerify != nil {
+		s["UAMOIM_INSECURE_SKIP_VERIFY"] = strconv.FormatBool(*p.InsecureSkipVerify)
+	}
+	return s
+}
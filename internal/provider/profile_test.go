@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testProfileFile = `
+profiles:
+  dev:
+    host: https://oim-dev.example.com
+    username: dev-user
+    password: dev-secret
+  prod:
+    host: https://oim.example.com
+    oauth:
+      token_url: https://login.example.com/oauth2/token
+      client_id: terraform
+      client_secret: prod-secret
+      scopes: [uam.read, uam.write]
+    ca_cert_file: /etc/pki/internal-ca.pem
+    tls_server_name: oim.internal
+`
+
+func writeProfileFile(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	file := writeProfileFile(t, testProfileFile)
+	t.Setenv("UAMOIM_HOST", "")
+	t.Setenv("UAMOIM_CLIENT_ID", "")
+	t.Setenv("UAMOIM_TLS_SERVER_NAME", "")
+
+	env, err := loadEnvironment("prod", file)
+	if err != nil {
+		t.Fatalf("loadEnvironment: %v", err)
+	}
+	if got := env.get("UAMOIM_HOST"); got != "https://oim.example.com" {
+		t.Errorf("host = %q", got)
+	}
+
+	// The profile selects OAuth2 and TLS settings.
+	var diags diag.Diagnostics
+	oauth := oauthConfig(t.Context(), uamoimProviderConfig{Username: types.StringNull()}, env, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if oauth == nil || oauth.ClientSecret != "prod-secret" || len(oauth.Scopes) != 2 {
+		t.Errorf("oauthConfig = %+v", oauth)
+	}
+
+	// The environment takes precedence over the profile, the provider
+	// configuration over both.
+	t.Setenv("UAMOIM_TLS_SERVER_NAME", "oim-env.internal")
+	tls := tlsConfig(uamoimProviderConfig{CACertFile: types.StringValue("/etc/pki/ca.pem")}, env, &diags)
+	if tls.CACertFile != "/etc/pki/ca.pem" || tls.ServerName != "oim-env.internal" {
+		t.Errorf("tlsConfig = %+v", tls)
+	}
+
+	// Without a profile the file is not read at all.
+	if env, err := loadEnvironment("", filepath.Join(t.TempDir(), "missing.yaml")); err != nil || env.get("UAMOIM_HOST") != "" {
+		t.Errorf("loadEnvironment without profile = %+v, %v", env, err)
+	}
+}
+
+func TestLoadEnvironmentErrors(t *testing.T) {
+	tests := map[string]struct {
+		profile, file string
+	}{
+		"missing file":    {"dev", filepath.Join(t.TempDir(), "missing.yaml")},
+		"missing profile": {"test", writeProfileFile(t, testProfileFile)},
+		"unknown setting": {"dev", writeProfileFile(t, "profiles:\n  dev:\n    hostname: https://oim-dev.example.com\n")},
+		"invalid YAML":    {"dev", writeProfileFile(t, "profiles: [")},
+	}
+	for name, tt := range tests {
+		if _, err := loadEnvironment(tt.profile, tt.file); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestConfigureEnvUsernameOverProfileOAuth(t *testing.T) {
+	file := writeProfileFile(t, `
+profiles:
+  prod:
+    host: https://oim.example.com
+    oauth:
+      token_url: https://login.example.com/oauth2/token
+      client_id: terraform
+      client_secret: prod-secret
+`)
+	t.Setenv("UAMOIM_CLIENT_ID", "")
+	t.Setenv("UAMOIM_USERNAME", "env-user")
+	t.Setenv("UAMOIM_PASSWORD", "env-secret")
+
+	// The environment username selects username and password authentication,
+	// so the OAuth2 settings of the profile are not used along with it.
+	configuredProviderServer(t, map[string]tftypes.Value{
+		"profile":      tftypes.NewValue(tftypes.String, "prod"),
+		"profile_file": tftypes.NewValue(tftypes.String, file),
+	})
+}
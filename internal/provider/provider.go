@@ -2,21 +2,32 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
-	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-uamoim/internal/uamclient"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &uamoimProvider{}
+	_ provider.Provider                       = &uamoimProvider{}
+	_ provider.ProviderWithEphemeralResources = &uamoimProvider{}
 )
 
 type uamoimProvider struct {
@@ -30,6 +41,62 @@ type uamoimProviderConfig struct {
 	Host     types.String `tfsdk:"host"`
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
+
+	Profile     types.String `tfsdk:"profile"`
+	ProfileFile types.String `tfsdk:"profile_file"`
+
+	DefaultApplication types.String `tfsdk:"default_application"`
+	ReadOnly           types.Bool   `tfsdk:"read_only"`
+	ChangeReference    types.String `tfsdk:"change_reference"`
+	AuditLogPath       types.String `tfsdk:"audit_log_path"`
+
+	ExpectedEnvironment types.String `tfsdk:"expected_environment"`
+	AllowedHosts        types.List   `tfsdk:"allowed_hosts"`
+
+	MaxRetries         types.Int64  `tfsdk:"max_retries"`
+	RetryMinWait       types.String `tfsdk:"retry_min_wait"`
+	RetryMaxWait       types.String `tfsdk:"retry_max_wait"`
+	RetryNonIdempotent types.Bool   `tfsdk:"retry_non_idempotent"`
+
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	TLSServerName      types.String `tfsdk:"tls_server_name"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	ProxyURL types.String `tfsdk:"proxy_url"`
+	NoProxy  types.String `tfsdk:"no_proxy"`
+	Headers  types.Map    `tfsdk:"headers"`
+
+	OAuth *uamoimOAuthConfig `tfsdk:"oauth"`
+	Cache *uamoimCacheConfig `tfsdk:"cache"`
+}
+
+// uamoimProviderData is passed to the Configure methods of all resources, data
+// sources and ephemeral resources.
+type uamoimProviderData struct {
+	Client *uamclient.Client
+	// DefaultApplication is used where application_name is omitted.
+	DefaultApplication string
+	// ChangeReference is sent with the changes of resources that do not
+	// set their own change_reference.
+	ChangeReference string
+}
+
+type uamoimOAuthConfig struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
+}
+
+type uamoimCacheConfig struct {
+	Enabled types.Bool   `tfsdk:"enabled"`
+	TTL     types.String `tfsdk:"ttl"`
 }
 
 func New(version string) func() provider.Provider {
@@ -58,6 +125,170 @@ func (p *uamoimProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Optional:  true,
 				Sensitive: true,
 			},
+			"profile": schema.StringAttribute{
+				Optional: true,
+				Description: "A profile of the profile file to take the host, authentication and TLS settings from, " +
+					"e.g. \"prod\". Settings in the provider block and UAMOIM_* environment variables take precedence. " +
+					"Can also be set with UAMOIM_PROFILE.",
+			},
+			"profile_file": schema.StringAttribute{
+				Optional: true,
+				Description: "The YAML file with the profiles. Can also be set with UAMOIM_CONFIG_FILE. " +
+					"Defaults to ~/.config/uamoim/config.yaml.",
+			},
+			"default_application": schema.StringAttribute{
+				Optional: true,
+				Description: "The application_name of all resources and data sources that do not set one. " +
+					"Can also be set with UAMOIM_DEFAULT_APPLICATION.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional: true,
+				Description: "Makes every create, update and delete fail before a request is sent, e.g. to plan with " +
+					"credentials that must never write. Data sources and plans keep working. " +
+					"Can also be set with UAMOIM_READ_ONLY. Defaults to false.",
+			},
+			"change_reference": schema.StringAttribute{
+				Optional: true,
+				Description: "The change reference, e.g. a ticket key, sent with every create, update and delete for the " +
+					"audit trail of UAM/OIM. Resources can override it with their own change_reference. " +
+					"Can also be set with UAMOIM_CHANGE_REFERENCE.",
+			},
+			"audit_log_path": schema.StringAttribute{
+				Optional: true,
+				Description: "A file to append a JSON line to for every create, update and delete request, with the time, " +
+					"method, object type and ID, the request body with secrets redacted, the response status, the change " +
+					"reference and the resource type and ID. Terraform does not tell providers the resource address. " +
+					"Can also be set with UAMOIM_AUDIT_LOG_PATH.",
+			},
+			"expected_environment": schema.StringAttribute{
+				Optional: true,
+				Description: "The environment the API must report, e.g. \"PROD\". The provider fails to configure if the " +
+					"host belongs to another environment. Can also be set with UAMOIM_EXPECTED_ENVIRONMENT.",
+			},
+			"allowed_hosts": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The hosts the provider may connect to, as host names or host:port. Any other host is rejected. " +
+					"Must not be empty. Can also be set, comma-separated, with UAMOIM_ALLOWED_HOSTS. Defaults to any host.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional: true,
+				Description: "How often a failed request is retried. Read requests and idempotent updates are retried " +
+					"after transport errors, gateway errors and rate limiting (HTTP 429). Defaults to 3, 0 disables retries.",
+			},
+			"retry_min_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: "The minimum wait before a retry as a Go duration, e.g. \"500ms\". Defaults to \"1s\".",
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Optional: true,
+				Description: "The maximum wait before a retry as a Go duration, e.g. \"1m\". Defaults to \"30s\". " +
+					"Requests the API asks to delay longer than this via Retry-After fail instead of waiting.",
+			},
+			"retry_non_idempotent": schema.BoolAttribute{
+				Optional: true,
+				Description: "Also retry rate limited (HTTP 429) create requests, which are not idempotent. " +
+					"Only enable this if the API rejects rate limited requests before processing them. Defaults to false.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional: true,
+				Description: "The maximum number of API requests in flight, shared by all resources and data sources " +
+					"regardless of Terraform's parallelism. Defaults to 0, which means unlimited.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional: true,
+				Description: "The maximum sustained API request rate, shared by all resources and data sources. " +
+					"Short bursts of up to one second's worth of requests are allowed. Defaults to 0, which means unlimited.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional: true,
+				Description: "A PEM file with CA certificates to trust in addition to the system roots, e.g. of an internal PKI. " +
+					"Can also be set with UAMOIM_CA_CERT_FILE.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional: true,
+				Description: "PEM encoded CA certificates to trust in addition to the system roots. " +
+					"Can also be set with UAMOIM_CA_CERT_PEM.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional: true,
+				Description: "A PEM file with the client certificate for mutual TLS with the uamoim API. Requires client_key_file. " +
+					"Can also be set with UAMOIM_CLIENT_CERT_FILE.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional: true,
+				Description: "A PEM file with the private key of the client certificate. " +
+					"Can also be set with UAMOIM_CLIENT_KEY_FILE.",
+			},
+			"tls_server_name": schema.StringAttribute{
+				Optional: true,
+				Description: "The name the certificate of the uamoim API is verified against, if it differs from the host name. " +
+					"Can also be set with UAMOIM_TLS_SERVER_NAME.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional: true,
+				Description: "Disables verification of the certificate of the uamoim API. Only meant for testing, " +
+					"as it makes the connection vulnerable to interception. Can also be set with UAMOIM_INSECURE_SKIP_VERIFY.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional: true,
+				Description: "The proxy for all requests, e.g. \"http://proxy.example.com:3128\". Can also be set with UAMOIM_PROXY_URL. " +
+					"Defaults to the HTTPS_PROXY and HTTP_PROXY environment variables.",
+			},
+			"no_proxy": schema.StringAttribute{
+				Optional: true,
+				Description: "A comma separated list of hosts, domains and CIDR ranges that are reached without the proxy, " +
+					"e.g. \".internal,10.0.0.0/8\". Can also be set with UAMOIM_NO_PROXY. Defaults to the NO_PROXY environment variable.",
+			},
+			"headers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Additional HTTP headers sent with every request, e.g. a tenant header. " +
+					"They cannot override the Accept, Content-Type and Authorization headers.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"oauth": schema.SingleNestedBlock{
+				Description: "Authenticates with OAuth2 client credentials instead of username and password. " +
+					"Each attribute can also be set with an environment variable. The configuration, then the environment variables, " +
+					"then the profile select the authentication: the oauth block or UAMOIM_CLIENT_ID selects OAuth2, a username selects " +
+					"username and password.",
+				Attributes: map[string]schema.Attribute{
+					"token_url": schema.StringAttribute{
+						Optional:    true,
+						Description: "The token endpoint of the authorization server. Can also be set with UAMOIM_TOKEN_URL.",
+					},
+					"client_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "The client ID of the service principal. Can also be set with UAMOIM_CLIENT_ID.",
+					},
+					"client_secret": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The client secret of the service principal. Can also be set with UAMOIM_CLIENT_SECRET.",
+					},
+					"scopes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "The scopes to request. Can also be set with UAMOIM_SCOPES as a comma or space separated list.",
+					},
+				},
+			},
+			"cache": schema.SingleNestedBlock{
+				Description: "Caches API reads for the duration of a Terraform run, so that e.g. many lookups of the same " +
+					"group name cost a single request. Concurrent identical reads are coalesced, and creating, updating or " +
+					"deleting an object drops the cached reads of its object type. Enabled by default.",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Whether API reads are cached. Defaults to true.",
+					},
+					"ttl": schema.StringAttribute{
+						Optional:    true,
+						Description: "How long a read is reused as a Go duration, e.g. \"30s\". Defaults to \"5m\".",
+					},
+				},
+			},
 		},
 	}
 }
@@ -101,17 +332,31 @@ func (p *uamoimProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	host := os.Getenv("UAMOIM_HOST")
-	username := os.Getenv("UAMOIM_USERNAME")
-	password := os.Getenv("UAMOIM_PASSWORD")
-	if !cfg.Host.IsNull() {
-		host = cfg.Host.ValueString()
-	}
-	if !cfg.Username.IsNull() {
-		username = cfg.Username.ValueString()
+	env, err := loadEnvironment(stringValue(cfg.Profile, "UAMOIM_PROFILE"), stringValue(cfg.ProfileFile, "UAMOIM_CONFIG_FILE"))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unable to Load uamoim Profile",
+			"The provider cannot load the selected connection profile: "+err.Error(),
+		)
+		return
 	}
-	if !cfg.Password.IsNull() {
-		password = cfg.Password.ValueString()
+
+	host := env.value(cfg.Host, "UAMOIM_HOST")
+	var username, password string
+	var oauth *uamclient.OAuthConfig
+	if useOAuth(cfg, env) {
+		// Only a username or password of the configuration is passed on,
+		// for the client to reject it along with OAuth2. Those of env are
+		// not meant for this sign-in.
+		username, password = cfg.Username.ValueString(), cfg.Password.ValueString()
+		oauth = oauthConfig(ctx, cfg, env, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		username = env.value(cfg.Username, "UAMOIM_USERNAME")
+		password = env.value(cfg.Password, "UAMOIM_PASSWORD")
 	}
 
 	if host == "" {
@@ -123,7 +368,7 @@ func (p *uamoimProvider) Configure(ctx context.Context, req provider.ConfigureRe
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
-	if username == "" {
+	if oauth == nil && username == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("username"),
 			"Missing uamoim API Username",
@@ -132,7 +377,7 @@ func (p *uamoimProvider) Configure(ctx context.Context, req provider.ConfigureRe
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
-	if password == "" {
+	if oauth == nil && password == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("password"),
 			"Missing uamoim API Password",
@@ -141,12 +386,66 @@ func (p *uamoimProvider) Configure(ctx context.Context, req provider.ConfigureRe
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
+	checkAllowedHost(host, allowedHosts(ctx, cfg, env, &resp.Diagnostics), &resp.Diagnostics)
+	expectedEnvironment := expectedEnvironment(cfg, env, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	retry := retryPolicy(cfg, &resp.Diagnostics)
+	maxConcurrent := cfg.MaxConcurrentRequests.ValueInt64()
+	if maxConcurrent < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid uamoim Rate Limit Configuration",
+			"max_concurrent_requests must not be negative.",
+		)
+	}
+	requestsPerSecond := cfg.RequestsPerSecond.ValueFloat64()
+	if requestsPerSecond < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid uamoim Rate Limit Configuration",
+			"requests_per_second must not be negative.",
+		)
+	}
+	cacheTTL := cacheTTL(cfg, &resp.Diagnostics)
+	readOnly := readOnly(cfg, &resp.Diagnostics)
+	tls := tlsConfig(cfg, env, &resp.Diagnostics)
+	headers := map[string]string{}
+	if !cfg.Headers.IsNull() && !cfg.Headers.IsUnknown() {
+		resp.Diagnostics.Append(cfg.Headers.ElementsAs(ctx, &headers, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auditLog := openAuditLog(cfg, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Debug(ctx, "Creating uamoim API client")
-	client, err := hashicups.NewClient(&host, &username, &password)
+	client, err := uamclient.NewClient(uamclient.Config{
+		Host:     host,
+		Username: username,
+		Password: password,
+		OAuth:    oauth,
+		TLS:      tls,
+		Retry:    retry,
+
+		ProxyURL:  stringValue(cfg.ProxyURL, "UAMOIM_PROXY_URL"),
+		NoProxy:   stringValue(cfg.NoProxy, "UAMOIM_NO_PROXY"),
+		Headers:   headers,
+		UserAgent: fmt.Sprintf("Terraform/%s terraform-provider-uamoim/%s", req.TerraformVersion, p.version),
+
+		MaxConcurrentRequests: int(maxConcurrent),
+		RequestsPerSecond:     requestsPerSecond,
+		CacheTTL:              cacheTTL,
+
+		ReadOnly: readOnly,
+		AuditLog: auditLog,
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create uamoim API Client",
@@ -157,20 +456,287 @@ func (p *uamoimProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	// Make the uamoim client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	checkEnvironment(ctx, client, expectedEnvironment, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make the uamoim client available during DataSource, Resource and
+	// EphemeralResource type Configure methods.
+	data := &uamoimProviderData{
+		Client:             client,
+		DefaultApplication: stringValue(cfg.DefaultApplication, "UAMOIM_DEFAULT_APPLICATION"),
+		ChangeReference:    stringValue(cfg.ChangeReference, "UAMOIM_CHANGE_REFERENCE"),
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
 }
 
 func (p *uamoimProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		NewShopsDataSource, NewSODsDataSource, NewCoffeesDataSource,
+		NewShopsDataSource, NewSODsDataSource, NewGroupsDataSource, NewCoffeesDataSource,
+		NewModuleByNameDataSource, NewGroupByNameDataSource, NewShopByNameDataSource, NewSoDClassByNameDataSource,
+	}
+}
+
+func (p *uamoimProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessTokenEphemeralResource,
 	}
 }
 
 func (p *uamoimProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
-		NewOrderResource,
+		NewOrderResource, NewModuleResource, NewModuleBISOResource, NewRoleAssignmentResource,
+	}
+}
+
+// retryPolicy returns the client retry policy for cfg, starting from the
+// client defaults.
+func retryPolicy(cfg uamoimProviderConfig, diags *diag.Diagnostics) uamclient.RetryPolicy {
+	retry := uamclient.DefaultRetryPolicy
+	if !cfg.MaxRetries.IsNull() && !cfg.MaxRetries.IsUnknown() {
+		retry.MaxRetries = int(cfg.MaxRetries.ValueInt64())
+		if retry.MaxRetries < 0 {
+			diags.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid uamoim Retry Configuration",
+				"max_retries must not be negative.",
+			)
+		}
+	}
+	retry.MinWait = parseDuration(cfg.RetryMinWait, path.Root("retry_min_wait"), retry.MinWait, diags)
+	retry.MaxWait = parseDuration(cfg.RetryMaxWait, path.Root("retry_max_wait"), retry.MaxWait, diags)
+	// An unknown value leaves the safe default.
+	retry.RetryNonIdempotent = cfg.RetryNonIdempotent.ValueBool()
+	if !diags.HasError() && retry.MinWait > retry.MaxWait {
+		diags.AddAttributeError(
+			path.Root("retry_min_wait"),
+			"Invalid uamoim Retry Configuration",
+			fmt.Sprintf("retry_min_wait (%s) must not be greater than retry_max_wait (%s).", retry.MinWait, retry.MaxWait),
+		)
+	}
+	return retry
+}
+
+// useOAuth reports whether the provider authenticates with OAuth2 client
+// credentials instead of username and password. The configuration decides
+// with the oauth block or username, then the environment variables with
+// UAMOIM_CLIENT_ID or UAMOIM_USERNAME, then the profile in the same way.
+func useOAuth(cfg uamoimProviderConfig, env environment) bool {
+	if cfg.OAuth != nil {
+		return true
+	}
+	if !cfg.Username.IsNull() {
+		return false
+	}
+	for _, setting := range []func(string) string{os.Getenv, env.profileSetting} {
+		if setting("UAMOIM_CLIENT_ID") != "" {
+			return true
+		}
+		if setting("UAMOIM_USERNAME") != "" {
+			return false
+		}
+	}
+	return false
+}
+
+// oauthConfig returns the OAuth2 client credentials from the oauth block and
+// the UAMOIM_TOKEN_URL, UAMOIM_CLIENT_ID, UAMOIM_CLIENT_SECRET and
+// UAMOIM_SCOPES settings of env. Values in the block take precedence.
+func oauthConfig(ctx context.Context, cfg uamoimProviderConfig, env environment, diags *diag.Diagnostics) *uamclient.OAuthConfig {
+	oauth := &uamclient.OAuthConfig{
+		TokenURL:     env.get("UAMOIM_TOKEN_URL"),
+		ClientID:     env.get("UAMOIM_CLIENT_ID"),
+		ClientSecret: env.get("UAMOIM_CLIENT_SECRET"),
+		Scopes: strings.FieldsFunc(env.get("UAMOIM_SCOPES"), func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		}),
+	}
+	if c := cfg.OAuth; c != nil {
+		for _, v := range []struct {
+			name  string
+			value attr.Value
+		}{
+			{"token_url", c.TokenURL}, {"client_id", c.ClientID}, {"client_secret", c.ClientSecret}, {"scopes", c.Scopes},
+		} {
+			if v.value.IsUnknown() {
+				diags.AddAttributeError(
+					path.Root("oauth").AtName(v.name),
+					"Unknown uamoim OAuth2 Configuration",
+					"The provider cannot create the uamoim API client as there is an unknown configuration value for oauth."+v.name+". "+
+						"Either target apply the source of the value first or set the value statically in the configuration.",
+				)
+			}
+		}
+		if diags.HasError() {
+			return nil
+		}
+		if !c.TokenURL.IsNull() {
+			oauth.TokenURL = c.TokenURL.ValueString()
+		}
+		if !c.ClientID.IsNull() {
+			oauth.ClientID = c.ClientID.ValueString()
+		}
+		if !c.ClientSecret.IsNull() {
+			oauth.ClientSecret = c.ClientSecret.ValueString()
+		}
+		if !c.Scopes.IsNull() {
+			oauth.Scopes = nil
+			diags.Append(c.Scopes.ElementsAs(ctx, &oauth.Scopes, false)...)
+		}
+	}
+
+	for _, required := range []struct{ name, env, value string }{
+		{"token_url", "UAMOIM_TOKEN_URL", oauth.TokenURL},
+		{"client_id", "UAMOIM_CLIENT_ID", oauth.ClientID},
+		{"client_secret", "UAMOIM_CLIENT_SECRET", oauth.ClientSecret},
+	} {
+		if required.value == "" {
+			diags.AddAttributeError(
+				path.Root("oauth").AtName(required.name),
+				"Missing uamoim OAuth2 Configuration",
+				"The provider cannot create the uamoim API client as there is a missing or empty value for oauth."+required.name+". "+
+					"Set the value in the oauth block or use the "+required.env+" environment variable.",
+			)
+		}
+	}
+	return oauth
+}
+
+// tlsConfig returns the client TLS configuration from cfg and the matching
+// UAMOIM_* settings of env, which are used for unset attributes.
+func tlsConfig(cfg uamoimProviderConfig, env environment, diags *diag.Diagnostics) uamclient.TLSConfig {
+	tls := uamclient.TLSConfig{
+		CACertFile:     env.value(cfg.CACertFile, "UAMOIM_CA_CERT_FILE"),
+		CACertPEM:      env.value(cfg.CACertPEM, "UAMOIM_CA_CERT_PEM"),
+		ClientCertFile: env.value(cfg.ClientCertFile, "UAMOIM_CLIENT_CERT_FILE"),
+		ClientKeyFile:  env.value(cfg.ClientKeyFile, "UAMOIM_CLIENT_KEY_FILE"),
+		ServerName:     env.value(cfg.TLSServerName, "UAMOIM_TLS_SERVER_NAME"),
+	}
+
+	if !cfg.InsecureSkipVerify.IsNull() {
+		tls.InsecureSkipVerify = cfg.InsecureSkipVerify.ValueBool()
+	} else if v := env.get("UAMOIM_INSECURE_SKIP_VERIFY"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid uamoim TLS Configuration",
+				fmt.Sprintf("UAMOIM_INSECURE_SKIP_VERIFY must be a boolean such as \"true\" or \"false\", got: %q", v),
+			)
+		}
+		tls.InsecureSkipVerify = insecure
+	}
+	if tls.InsecureSkipVerify {
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"uamoim Server Certificate Verification Disabled",
+			"insecure_skip_verify is enabled, so the provider does not verify the certificate of the uamoim API. "+
+				"Anyone able to intercept the connection can read the credentials and modify the requests. "+
+				"Trust the CA of the API with ca_cert_file or ca_cert_pem instead.",
+		)
+	}
+
+	if (tls.ClientCertFile == "") != (tls.ClientKeyFile == "") {
+		diags.AddAttributeError(
+			path.Root("client_cert_file"),
+			"Invalid uamoim TLS Configuration",
+			"client_cert_file and client_key_file must be set together.",
+		)
+	}
+	return tls
+}
+
+// stringValue returns the value of v, or of the environment variable env if
+// v is not set.
+func stringValue(v types.String, env string) string {
+	if v.IsNull() || v.IsUnknown() {
+		return os.Getenv(env)
+	}
+	return v.ValueString()
+}
+
+// openAuditLog opens the audit log file of cfg or UAMOIM_AUDIT_LOG_PATH for
+// appending. It returns nil if no audit log is configured.
+func openAuditLog(cfg uamoimProviderConfig, diags *diag.Diagnostics) io.Writer {
+	if cfg.AuditLogPath.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("audit_log_path"),
+			"Unknown uamoim Audit Log Path",
+			"The provider cannot open the audit log as there is an unknown configuration value for the audit log path. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the UAMOIM_AUDIT_LOG_PATH environment variable.",
+		)
+		return nil
+	}
+	auditLogPath := stringValue(cfg.AuditLogPath, "UAMOIM_AUDIT_LOG_PATH")
+	if auditLogPath == "" {
+		return nil
+	}
+	// The file stays open for the lifetime of the provider process.
+	f, err := os.OpenFile(auditLogPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("audit_log_path"),
+			"Unable to Open uamoim Audit Log",
+			"The provider cannot open the audit log: "+err.Error(),
+		)
+		return nil
+	}
+	return f
+}
+
+// readOnly reports whether cfg or UAMOIM_READ_ONLY make the provider
+// read-only.
+func readOnly(cfg uamoimProviderConfig, diags *diag.Diagnostics) bool {
+	if cfg.ReadOnly.IsUnknown() {
+		// Err on the safe side until the value is known.
+		return true
+	}
+	if !cfg.ReadOnly.IsNull() {
+		return cfg.ReadOnly.ValueBool()
+	}
+	v := os.Getenv("UAMOIM_READ_ONLY")
+	if v == "" {
+		return false
+	}
+	readOnly, err := strconv.ParseBool(v)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("read_only"),
+			"Invalid uamoim Read-Only Configuration",
+			fmt.Sprintf("UAMOIM_READ_ONLY must be a boolean such as \"true\" or \"false\", got: %q", v),
+		)
+	}
+	return readOnly
+}
+
+// cacheTTL returns the client cache TTL for cfg; zero disables the cache.
+func cacheTTL(cfg uamoimProviderConfig, diags *diag.Diagnostics) time.Duration {
+	if cfg.Cache == nil {
+		return uamclient.DefaultCacheTTL
+	}
+	if !cfg.Cache.Enabled.IsNull() && !cfg.Cache.Enabled.ValueBool() {
+		return 0
+	}
+	return parseDuration(cfg.Cache.TTL, path.Root("cache").AtName("ttl"), uamclient.DefaultCacheTTL, diags)
+}
+
+// parseDuration parses the non-negative duration in v, returning def when v
+// is not set.
+func parseDuration(v types.String, p path.Path, def time.Duration, diags *diag.Diagnostics) time.Duration {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	d, err := time.ParseDuration(v.ValueString())
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			p,
+			"Invalid Duration",
+			fmt.Sprintf("Expected a non-negative Go duration such as \"30s\" or \"1m\", got: %q", v.ValueString()),
+		)
+		return def
 	}
+	return d
 }
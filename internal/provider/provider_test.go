@@ -4,11 +4,19 @@
 package provider
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+
+	"terraform-provider-uamoim/internal/uamclient"
 )
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
@@ -18,13 +26,13 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
 }
 
-// testAccProtoV6ProviderFactoriesWithEcho includes the echo provider alongside the scaffolding provider.
+// testAccProtoV6ProviderFactoriesWithEcho includes the echo provider alongside the uamoim provider.
 // It allows for testing assertions on data returned by an ephemeral resource during Open.
 // The echoprovider is used to arrange tests by echoing ephemeral data into the Terraform state.
 // This lets the data be referenced in test assertions with state checks.
 var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
-	"echo":        echoprovider.NewProviderServer(),
+	"uamoim": providerserver.NewProtocol6WithError(New("test")()),
+	"echo":   echoprovider.NewProviderServer(),
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -32,3 +40,225 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestRetryPolicy(t *testing.T) {
+	var diags diag.Diagnostics
+	retry := retryPolicy(uamoimProviderConfig{
+		MaxRetries:         types.Int64Value(5),
+		RetryMinWait:       types.StringValue("250ms"),
+		RetryMaxWait:       types.StringNull(),
+		RetryNonIdempotent: types.BoolValue(true),
+	}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	want := uamclient.RetryPolicy{
+		MaxRetries:         5,
+		MinWait:            250 * time.Millisecond,
+		MaxWait:            uamclient.DefaultRetryPolicy.MaxWait,
+		RetryNonIdempotent: true,
+	}
+	if retry != want {
+		t.Errorf("retryPolicy = %+v, want %+v", retry, want)
+	}
+
+	invalid := []uamoimProviderConfig{
+		{MaxRetries: types.Int64Value(-1)},
+		{RetryMinWait: types.StringValue("soon")},
+		{RetryMinWait: types.StringValue("1m"), RetryMaxWait: types.StringValue("1s")},
+	}
+	for _, cfg := range invalid {
+		var diags diag.Diagnostics
+		if retryPolicy(cfg, &diags); !diags.HasError() {
+			t.Errorf("retryPolicy(%+v): expected error", cfg)
+		}
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	tests := []struct {
+		cache *uamoimCacheConfig
+		want  time.Duration
+	}{
+		{nil, uamclient.DefaultCacheTTL},
+		{&uamoimCacheConfig{Enabled: types.BoolNull(), TTL: types.StringNull()}, uamclient.DefaultCacheTTL},
+		{&uamoimCacheConfig{Enabled: types.BoolValue(true), TTL: types.StringValue("30s")}, 30 * time.Second},
+		{&uamoimCacheConfig{Enabled: types.BoolValue(false), TTL: types.StringValue("30s")}, 0},
+	}
+	for _, tt := range tests {
+		var diags diag.Diagnostics
+		if got := cacheTTL(uamoimProviderConfig{Cache: tt.cache}, &diags); got != tt.want || diags.HasError() {
+			t.Errorf("cacheTTL(%+v) = %s, %v, want %s", tt.cache, got, diags, tt.want)
+		}
+	}
+
+	var diags diag.Diagnostics
+	if cacheTTL(uamoimProviderConfig{Cache: &uamoimCacheConfig{TTL: types.StringValue("forever")}}, &diags); !diags.HasError() {
+		t.Error("expected error for invalid ttl")
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	tests := []struct {
+		readOnly types.Bool
+		env      string
+		want     bool
+	}{
+		{types.BoolNull(), "", false},
+		{types.BoolNull(), "true", true},
+		{types.BoolValue(false), "true", false},
+		{types.BoolValue(true), "", true},
+		{types.BoolUnknown(), "", true},
+	}
+	for _, tt := range tests {
+		t.Setenv("UAMOIM_READ_ONLY", tt.env)
+		var diags diag.Diagnostics
+		if got := readOnly(uamoimProviderConfig{ReadOnly: tt.readOnly}, &diags); got != tt.want || diags.HasError() {
+			t.Errorf("readOnly(%s, UAMOIM_READ_ONLY=%q) = %t, %v, want %t", tt.readOnly, tt.env, got, diags, tt.want)
+		}
+	}
+
+	t.Setenv("UAMOIM_READ_ONLY", "yes please")
+	var diags diag.Diagnostics
+	if readOnly(uamoimProviderConfig{ReadOnly: types.BoolNull()}, &diags); !diags.HasError() {
+		t.Error("expected error for invalid UAMOIM_READ_ONLY")
+	}
+}
+
+func TestOpenAuditLog(t *testing.T) {
+	t.Setenv("UAMOIM_AUDIT_LOG_PATH", "")
+	var diags diag.Diagnostics
+	if w := openAuditLog(uamoimProviderConfig{AuditLogPath: types.StringNull()}, &diags); w != nil || diags.HasError() {
+		t.Errorf("openAuditLog without a path = %v, %v, want nil", w, diags)
+	}
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	t.Setenv("UAMOIM_AUDIT_LOG_PATH", logPath)
+	w := openAuditLog(uamoimProviderConfig{AuditLogPath: types.StringNull()}, &diags)
+	f, ok := w.(*os.File)
+	if !ok || diags.HasError() {
+		t.Fatalf("openAuditLog(UAMOIM_AUDIT_LOG_PATH=%q) = %v, %v, want the file", logPath, w, diags)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if f.Name() != logPath {
+		t.Errorf("opened %s, want %s", f.Name(), logPath)
+	}
+
+	if w := openAuditLog(uamoimProviderConfig{AuditLogPath: types.StringUnknown()}, &diags); w != nil || !diags.HasError() {
+		t.Errorf("openAuditLog with an unknown path = %v, %v, want an error", w, diags)
+	}
+}
+
+func TestOAuthConfig(t *testing.T) {
+	t.Setenv("UAMOIM_TOKEN_URL", "https://login.example.com/token")
+	t.Setenv("UAMOIM_CLIENT_ID", "env-client")
+	t.Setenv("UAMOIM_CLIENT_SECRET", "env-secret")
+	t.Setenv("UAMOIM_SCOPES", "uam.read, uam.write")
+	ctx := t.Context()
+
+	var diags diag.Diagnostics
+	oauth := oauthConfig(ctx, uamoimProviderConfig{Username: types.StringNull()}, environment{}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if oauth == nil || oauth.ClientID != "env-client" || len(oauth.Scopes) != 2 || oauth.Scopes[1] != "uam.write" {
+		t.Errorf("oauthConfig from environment = %+v", oauth)
+	}
+
+	// The block takes precedence over the environment.
+	oauth = oauthConfig(ctx, uamoimProviderConfig{
+		Username: types.StringNull(),
+		OAuth: &uamoimOAuthConfig{
+			TokenURL:     types.StringNull(),
+			ClientID:     types.StringValue("cfg-client"),
+			ClientSecret: types.StringNull(),
+			Scopes:       types.ListValueMust(types.StringType, []attr.Value{types.StringValue("uam.admin")}),
+		},
+	}, environment{}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if oauth.ClientID != "cfg-client" || oauth.ClientSecret != "env-secret" || len(oauth.Scopes) != 1 {
+		t.Errorf("oauthConfig from block = %+v", oauth)
+	}
+
+	t.Setenv("UAMOIM_CLIENT_SECRET", "")
+	oauthConfig(ctx, uamoimProviderConfig{Username: types.StringNull()}, environment{}, &diags)
+	if !diags.HasError() {
+		t.Error("expected error for missing client secret")
+	}
+}
+
+func TestUseOAuth(t *testing.T) {
+	oauthProfile := environment{profile: map[string]string{"UAMOIM_CLIENT_ID": "profile-client"}}
+	userProfile := environment{profile: map[string]string{"UAMOIM_USERNAME": "profile-user"}}
+	tests := map[string]struct {
+		cfg                  uamoimProviderConfig
+		envClientID, envUser string
+		env                  environment
+		want                 bool
+	}{
+		"nothing":                     {cfg: uamoimProviderConfig{Username: types.StringNull()}},
+		"oauth block":                 {cfg: uamoimProviderConfig{Username: types.StringNull(), OAuth: &uamoimOAuthConfig{}}, envUser: "env-user", want: true},
+		"username over env client ID": {cfg: uamoimProviderConfig{Username: types.StringValue("user")}, envClientID: "env-client"},
+		"env client ID":               {cfg: uamoimProviderConfig{Username: types.StringNull()}, envClientID: "env-client", env: userProfile, want: true},
+		"env username over profile":   {cfg: uamoimProviderConfig{Username: types.StringNull()}, envUser: "env-user", env: oauthProfile},
+		"profile client ID":           {cfg: uamoimProviderConfig{Username: types.StringNull()}, env: oauthProfile, want: true},
+		"profile username":            {cfg: uamoimProviderConfig{Username: types.StringNull()}, env: userProfile},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("UAMOIM_CLIENT_ID", tt.envClientID)
+			t.Setenv("UAMOIM_USERNAME", tt.envUser)
+			if got := useOAuth(tt.cfg, tt.env); got != tt.want {
+				t.Errorf("useOAuth = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	t.Setenv("UAMOIM_CA_CERT_FILE", "/etc/pki/env-ca.pem")
+	t.Setenv("UAMOIM_TLS_SERVER_NAME", "oim.internal")
+
+	var diags diag.Diagnostics
+	tls := tlsConfig(uamoimProviderConfig{
+		CACertFile:     types.StringValue("/etc/pki/ca.pem"),
+		ClientCertFile: types.StringValue("client.crt"),
+		ClientKeyFile:  types.StringValue("client.key"),
+	}, environment{}, &diags)
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	want := uamclient.TLSConfig{
+		CACertFile:     "/etc/pki/ca.pem",
+		ClientCertFile: "client.crt",
+		ClientKeyFile:  "client.key",
+		ServerName:     "oim.internal",
+	}
+	if tls != want {
+		t.Errorf("tlsConfig = %+v, want %+v", tls, want)
+	}
+
+	t.Setenv("UAMOIM_INSECURE_SKIP_VERIFY", "true")
+	if tls := tlsConfig(uamoimProviderConfig{}, environment{}, &diags); !tls.InsecureSkipVerify || diags.WarningsCount() != 1 {
+		t.Errorf("insecure_skip_verify from environment: %+v, %v", tls, diags)
+	}
+
+	invalid := []struct {
+		cfg uamoimProviderConfig
+		env string
+	}{
+		{uamoimProviderConfig{}, "maybe"},
+		{uamoimProviderConfig{ClientCertFile: types.StringValue("client.crt")}, ""},
+	}
+	for _, tt := range invalid {
+		t.Setenv("UAMOIM_INSECURE_SKIP_VERIFY", tt.env)
+		var diags diag.Diagnostics
+		if tlsConfig(tt.cfg, environment{}, &diags); !diags.HasError() {
+			t.Errorf("tlsConfig(%+v) with UAMOIM_INSECURE_SKIP_VERIFY=%q: expected error", tt.cfg, tt.env)
+		}
+	}
+}
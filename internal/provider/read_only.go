@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// checkWritable adds an error with summary to diags and returns false if the
+// provider is read-only. Create, Update and Delete call it before anything
+// else, so that not even a lookup is sent to the API; the client itself
+// rejects the changing request as well.
+func checkWritable(client *uamclient.Client, diags *diag.Diagnostics, summary string) bool {
+	if client == nil || !client.ReadOnly() {
+		return true
+	}
+	diags.AddError(
+		summary,
+		"The provider is read-only, so it does not create, update or delete objects. "+
+			"Unset read_only in the provider configuration, or UAMOIM_READ_ONLY, to apply changes.",
+	)
+	return false
+}
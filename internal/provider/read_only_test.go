@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// newReadOnlyProviderData returns the provider data with a read-only client
+// for a test server serving handler.
+func newReadOnlyProviderData(t *testing.T, handler http.Handler) *uamoimProviderData {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := uamclient.NewClient(uamclient.Config{Host: srv.URL, Username: "user", Password: "secret", ReadOnly: true})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return &uamoimProviderData{Client: client}
+}
+
+// A read-only provider fails Create, Update and Delete before it sends any
+// request, not even to sign in.
+func TestReadOnlyResourceChanges(t *testing.T) {
+	data := newReadOnlyProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	r := &moduleResource{}
+	s := configureResource(t, r, data)
+	ctx := t.Context()
+
+	model := moduleResourceModel{
+		ID:              types.StringValue("m-1"),
+		ApplicationName: types.StringValue("Application"),
+		ModuleName:      types.StringValue("OSKA"),
+		Description:     types.StringValue(""),
+		ChangeReference: types.StringNull(),
+		Timeouts:        nullTimeouts(),
+	}
+	plan, state := testPlan(t, s, &model), testState(t, s, &model)
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	updateResp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &updateResp)
+	deleteResp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &deleteResp)
+
+	for op, diags := range map[string]diag.Diagnostics{
+		"Create": createResp.Diagnostics,
+		"Update": updateResp.Diagnostics,
+		"Delete": deleteResp.Diagnostics,
+	} {
+		if !diags.HasError() || !strings.HasPrefix(diags.Errors()[0].Detail(), "The provider is read-only") {
+			t.Errorf("%s on a read-only provider: %v, want the read-only error", op, diags)
+		}
+	}
+}
+
+// Data sources only read, so they work with a read-only provider.
+func TestReadOnlyDataSourceRead(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(t, w, http.StatusOK, map[string]string{"token": "tok"})
+	})
+	mux.HandleFunc("GET /api/v1/groups", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(t, w, http.StatusOK, map[string]any{"items": []uamclient.Group{{ID: "g-1", Name: "XZ41234"}}})
+	})
+	resp := readDataSource(t, NewGroupByNameDataSource(), newReadOnlyProviderData(t, mux), &groupByNameDataSourceModel{
+		GroupName:   types.StringValue("XZ41234"),
+		ID:          types.StringNull(),
+		Description: types.StringNull(),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	var got groupByNameDataSourceModel
+	getState(t, resp.State, &got)
+	if got.ID.ValueString() != "g-1" {
+		t.Errorf("id = %s, want g-1", got.ID)
+	}
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// The resource and data source tests call the CRUD methods directly against
+// an httptest server, so that they run without Terraform.
+
+// newTestProviderData returns the provider data with an anonymous client for
+// a test server serving handler.
+func newTestProviderData(t *testing.T, handler http.Handler) *uamoimProviderData {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := uamclient.NewClient(uamclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return &uamoimProviderData{Client: client}
+}
+
+// configureResource configures r with data and returns its schema.
+func configureResource(t *testing.T, r resource.Resource, data *uamoimProviderData) schema.Schema {
+	t.Helper()
+	ctx := t.Context()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema: %v", schemaResp.Diagnostics)
+	}
+	if rc, ok := r.(resource.ResourceWithConfigure); ok {
+		var resp resource.ConfigureResponse
+		rc.Configure(ctx, resource.ConfigureRequest{ProviderData: data}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Configure: %v", resp.Diagnostics)
+		}
+	}
+	return schemaResp.Schema
+}
+
+// configureDataSource configures d with data and returns its schema.
+func configureDataSource(t *testing.T, d datasource.DataSource, data *uamoimProviderData) datasourceschema.Schema {
+	t.Helper()
+	ctx := t.Context()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema: %v", schemaResp.Diagnostics)
+	}
+	if dc, ok := d.(datasource.DataSourceWithConfigure); ok {
+		var resp datasource.ConfigureResponse
+		dc.Configure(ctx, datasource.ConfigureRequest{ProviderData: data}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Configure: %v", resp.Diagnostics)
+		}
+	}
+	return schemaResp.Schema
+}
+
+// schemaWithType is implemented by the resource and data source schemas.
+type schemaWithType interface {
+	Type() attr.Type
+}
+
+// nullValue returns the null object of the type of s.
+func nullValue(s schemaWithType) tftypes.Value {
+	return tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)
+}
+
+// testState returns the state of schema s holding model.
+func testState(t *testing.T, s schema.Schema, model any) tfsdk.State {
+	t.Helper()
+	state := tfsdk.State{Schema: s, Raw: nullValue(s)}
+	if diags := state.Set(t.Context(), model); diags.HasError() {
+		t.Fatalf("set state: %v", diags)
+	}
+	return state
+}
+
+// testPlan returns the plan of schema s holding model.
+func testPlan(t *testing.T, s schema.Schema, model any) tfsdk.Plan {
+	t.Helper()
+	return tfsdk.Plan{Schema: s, Raw: testState(t, s, model).Raw}
+}
+
+// testConfig returns the configuration of data source schema s holding
+// model.
+func testConfig(t *testing.T, s datasourceschema.Schema, model any) tfsdk.Config {
+	t.Helper()
+	state := tfsdk.State{Schema: s, Raw: nullValue(s)}
+	if diags := state.Set(t.Context(), model); diags.HasError() {
+		t.Fatalf("set config: %v", diags)
+	}
+	return tfsdk.Config{Schema: s, Raw: state.Raw}
+}
+
+// readDataSource configures d with data and reads it with the
+// configuration holding model.
+func readDataSource(t *testing.T, d datasource.DataSource, data *uamoimProviderData, model any) datasource.ReadResponse {
+	t.Helper()
+	s := configureDataSource(t, d, data)
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	d.Read(t.Context(), datasource.ReadRequest{Config: testConfig(t, s, model)}, &resp)
+	return resp
+}
+
+// attributeError returns the summary of the error on the attribute p in
+// diags, or "" if there is none.
+func attributeError(diags diag.Diagnostics, p path.Path) string {
+	for _, d := range diags.Errors() {
+		if d, ok := d.(diag.DiagnosticWithPath); ok && d.Path().Equal(p) {
+			return d.Summary()
+		}
+	}
+	return ""
+}
+
+// getState decodes state into model.
+func getState(t *testing.T, state tfsdk.State, model any) {
+	t.Helper()
+	if diags := state.Get(t.Context(), model); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+}
+
+// nullTimeouts returns an unset timeouts block.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	})}
+}
+
+// writeTestJSON writes v as a JSON response with the given status.
+func writeTestJSON(t *testing.T, w http.ResponseWriter, status int, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encode response: %v", err)
+	}
+}
@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &roleAssignmentResource{}
+	_ resource.ResourceWithConfigure   = &roleAssignmentResource{}
+	_ resource.ResourceWithModifyPlan  = &roleAssignmentResource{}
+	_ resource.ResourceWithImportState = &roleAssignmentResource{}
+)
+
+// NewRoleAssignmentResource is a helper function to simplify the provider implementation.
+func NewRoleAssignmentResource() resource.Resource {
+	return &roleAssignmentResource{}
+}
+
+// roleAssignmentResource is the resource implementation.
+type roleAssignmentResource struct {
+	client             *uamclient.Client
+	defaultApplication string
+	changeReference    string
+}
+
+// roleAssignmentResourceModel maps the resource schema data.
+type roleAssignmentResourceModel struct {
+	ID              types.String   `tfsdk:"id"`
+	ApplicationName types.String   `tfsdk:"application_name"`
+	ModuleID        types.String   `tfsdk:"module_id"`
+	GroupID         types.String   `tfsdk:"group_id"`
+	ShopID          types.String   `tfsdk:"shop_id"`
+	SoDClassID      types.String   `tfsdk:"sod_class_id"`
+	OrderFor        types.String   `tfsdk:"order_for"`
+	ApprovalFlow    types.String   `tfsdk:"approval_flow"`
+	Description     types.String   `tfsdk:"description"`
+	CanFachrolle    types.Bool     `tfsdk:"can_fachrolle"`
+	ChangeReference types.String   `tfsdk:"change_reference"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// roleAssignmentAPIFields maps API request fields to the attributes they come from.
+var roleAssignmentAPIFields = rootPaths(
+	"application_name", "module_id", "group_id", "shop_id", "sod_class_id",
+	"order_for", "approval_flow", "description", "can_fachrolle",
+)
+
+func (m *roleAssignmentResourceModel) fromAPI(ra *uamclient.RoleAssignment) {
+	m.ID = types.StringValue(ra.ID)
+	m.ApplicationName = types.StringValue(ra.ApplicationName)
+	m.ModuleID = types.StringValue(ra.ModuleID)
+	m.GroupID = types.StringValue(ra.GroupID)
+	m.ShopID = types.StringValue(ra.ShopID)
+	m.SoDClassID = types.StringValue(ra.SoDClassID)
+	m.OrderFor = types.StringValue(ra.OrderFor)
+	m.ApprovalFlow = types.StringValue(ra.ApprovalFlow)
+	m.Description = types.StringValue(ra.Description)
+	m.CanFachrolle = types.BoolValue(ra.CanFachrolle)
+}
+
+func (r *roleAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = data.Client
+	r.defaultApplication = data.DefaultApplication
+	r.changeReference = data.ChangeReference
+}
+
+// Metadata returns the resource type name.
+func (r *roleAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_assignment"
+}
+
+// Schema defines the schema for the resource.
+func (r *roleAssignmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assigns a role group to a UAM/OIM module and the shop it is ordered from.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the role assignment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"application_name": applicationNameAttribute("The name of the application the module belongs to. Changing it forces a new role assignment."),
+			"module_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the module. Changing it forces a new role assignment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the role group. Changing it forces a new role assignment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"shop_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the shop the role is ordered from.",
+			},
+			"sod_class_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the SoD class of the role.",
+			},
+			"order_for": schema.StringAttribute{
+				Required:    true,
+				Description: "Who may order the role, e.g. \"Alle internen und externen Mitarbeiter\".",
+			},
+			"approval_flow": schema.StringAttribute{
+				Required:    true,
+				Description: "The approval flow of an order, e.g. \"Vorgesetzter und BISO\".",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "The description of the role assignment.",
+			},
+			"can_fachrolle": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the role may be used as a Fachrolle (business role).",
+			},
+			"change_reference": changeReferenceAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+// ModifyPlan plans the default application and change reference of the
+// provider if application_name or change_reference is omitted.
+func (r *roleAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planApplicationName(ctx, r.defaultApplication, req, resp)
+	planChangeReference(ctx, r.changeReference, req, resp)
+}
+
+// Create creates the role assignment and sets the initial Terraform state.
+func (r *roleAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Creating uamoim Role Assignment") {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	ctx = uamclient.WithChangeReference(ctx, plan.ChangeReference.ValueString())
+	ctx = uamclient.WithResource(ctx, "uamoim_role_assignment", plan.ID.ValueString())
+
+	ra, err := r.client.RoleAssignments.Create(ctx, uamclient.RoleAssignmentCreate{
+		ApplicationName: plan.ApplicationName.ValueString(),
+		ModuleID:        plan.ModuleID.ValueString(),
+		GroupID:         plan.GroupID.ValueString(),
+		ShopID:          plan.ShopID.ValueString(),
+		SoDClassID:      plan.SoDClassID.ValueString(),
+		OrderFor:        plan.OrderFor.ValueString(),
+		ApprovalFlow:    plan.ApprovalFlow.ValueString(),
+		Description:     plan.Description.ValueString(),
+		CanFachrolle:    plan.CanFachrolle.ValueBool(),
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Creating uamoim Role Assignment",
+			"Could not assign group "+plan.GroupID.ValueString()+" to module "+plan.ModuleID.ValueString(),
+			err, roleAssignmentAPIFields,
+		)
+		return
+	}
+
+	plan.fromAPI(ra)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data. A role assignment
+// that was deleted outside of Terraform is removed from the state.
+func (r *roleAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ra, err := r.client.RoleAssignments.Get(ctx, state.ID.ValueString())
+	if uamclient.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Reading uamoim Role Assignment",
+			"Could not read role assignment ID "+state.ID.ValueString(),
+			err, nil,
+		)
+		return
+	}
+
+	state.fromAPI(ra)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the role assignment and sets the updated Terraform state on success.
+func (r *roleAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan roleAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Updating uamoim Role Assignment") {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	ctx = uamclient.WithChangeReference(ctx, plan.ChangeReference.ValueString())
+	ctx = uamclient.WithResource(ctx, "uamoim_role_assignment", plan.ID.ValueString())
+
+	ra, err := r.client.RoleAssignments.Update(ctx, plan.ID.ValueString(), uamclient.RoleAssignmentUpdate{
+		ShopID:       plan.ShopID.ValueString(),
+		SoDClassID:   plan.SoDClassID.ValueString(),
+		OrderFor:     plan.OrderFor.ValueString(),
+		ApprovalFlow: plan.ApprovalFlow.ValueString(),
+		Description:  plan.Description.ValueString(),
+		CanFachrolle: plan.CanFachrolle.ValueBool(),
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Updating uamoim Role Assignment",
+			"Could not update role assignment ID "+plan.ID.ValueString(),
+			err, roleAssignmentAPIFields,
+		)
+		return
+	}
+
+	plan.fromAPI(ra)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the role assignment and removes the Terraform state on success.
+func (r *roleAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !checkWritable(r.client, &resp.Diagnostics, "Error Deleting uamoim Role Assignment") {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = deleteChangeReference(ctx, r.changeReference, state.ChangeReference)
+	ctx = uamclient.WithResource(ctx, "uamoim_role_assignment", state.ID.ValueString())
+
+	err := r.client.RoleAssignments.Delete(ctx, state.ID.ValueString())
+	if err != nil && !uamclient.IsNotFound(err) {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Deleting uamoim Role Assignment",
+			"Could not delete role assignment ID "+state.ID.ValueString(),
+			err, nil,
+		)
+	}
+}
+
+// ImportState imports a role assignment by its ID.
+func (r *roleAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
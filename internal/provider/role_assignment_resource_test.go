@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+var testRoleAssignment = uamclient.RoleAssignment{
+	ID:              "ra-1",
+	ApplicationName: "Application",
+	ModuleID:        "m-1",
+	GroupID:         "g-1",
+	ShopID:          "s-1",
+	SoDClassID:      "sod-1",
+	OrderFor:        "Alle internen und externen Mitarbeiter",
+	ApprovalFlow:    "Vorgesetzter und BISO",
+	Description:     "Leser",
+	CanFachrolle:    false,
+}
+
+// fakeRoleAssignment serves a single role assignment.
+type fakeRoleAssignment struct {
+	mu      sync.Mutex
+	ra      uamclient.RoleAssignment
+	updates int
+}
+
+func (f *fakeRoleAssignment) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/role-assignments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if r.PathValue("id") != f.ra.ID {
+			writeTestJSON(t, w, http.StatusNotFound, map[string]string{"message": "role assignment not found"})
+			return
+		}
+		writeTestJSON(t, w, http.StatusOK, f.ra)
+	})
+	mux.HandleFunc("PUT /api/v1/role-assignments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var in uamclient.RoleAssignmentUpdate
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if r.PathValue("id") != f.ra.ID {
+			t.Errorf("PUT role assignment %q, want %q", r.PathValue("id"), f.ra.ID)
+		}
+		f.updates++
+		f.ra.ShopID = in.ShopID
+		f.ra.SoDClassID = in.SoDClassID
+		f.ra.OrderFor = in.OrderFor
+		f.ra.ApprovalFlow = in.ApprovalFlow
+		f.ra.Description = in.Description
+		f.ra.CanFachrolle = in.CanFachrolle
+		writeTestJSON(t, w, http.StatusOK, f.ra)
+	})
+	return mux
+}
+
+func (f *fakeRoleAssignment) change(fn func(*uamclient.RoleAssignment)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn(&f.ra)
+}
+
+func testRoleAssignmentModel(ra uamclient.RoleAssignment) roleAssignmentResourceModel {
+	var m roleAssignmentResourceModel
+	m.fromAPI(&ra)
+	m.ChangeReference = types.StringNull()
+	m.Timeouts = nullTimeouts()
+	return m
+}
+
+// roleAssignmentOf returns the role assignment held by m.
+func roleAssignmentOf(m roleAssignmentResourceModel) uamclient.RoleAssignment {
+	return uamclient.RoleAssignment{
+		ID:              m.ID.ValueString(),
+		ApplicationName: m.ApplicationName.ValueString(),
+		ModuleID:        m.ModuleID.ValueString(),
+		GroupID:         m.GroupID.ValueString(),
+		ShopID:          m.ShopID.ValueString(),
+		SoDClassID:      m.SoDClassID.ValueString(),
+		OrderFor:        m.OrderFor.ValueString(),
+		ApprovalFlow:    m.ApprovalFlow.ValueString(),
+		Description:     m.Description.ValueString(),
+		CanFachrolle:    m.CanFachrolle.ValueBool(),
+	}
+}
+
+func TestRoleAssignmentResourceReadDetectsDrift(t *testing.T) {
+	tests := map[string]func(*uamclient.RoleAssignment){
+		"shop_id":       func(ra *uamclient.RoleAssignment) { ra.ShopID = "s-2" },
+		"sod_class_id":  func(ra *uamclient.RoleAssignment) { ra.SoDClassID = "sod-2" },
+		"order_for":     func(ra *uamclient.RoleAssignment) { ra.OrderFor = "Nur interne Mitarbeiter" },
+		"approval_flow": func(ra *uamclient.RoleAssignment) { ra.ApprovalFlow = "Vorgesetzter" },
+		"description":   func(ra *uamclient.RoleAssignment) { ra.Description = "Schreiber" },
+		"can_fachrolle": func(ra *uamclient.RoleAssignment) { ra.CanFachrolle = true },
+	}
+	for attr, change := range tests {
+		t.Run(attr, func(t *testing.T) {
+			api := &fakeRoleAssignment{ra: testRoleAssignment}
+			r := &roleAssignmentResource{}
+			s := configureResource(t, r, newTestProviderData(t, api.handler(t)))
+
+			model := testRoleAssignmentModel(testRoleAssignment)
+			state := testState(t, s, &model)
+			api.change(change)
+			resp := resource.ReadResponse{State: state}
+			r.Read(t.Context(), resource.ReadRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read: %v", resp.Diagnostics)
+			}
+
+			var got roleAssignmentResourceModel
+			getState(t, resp.State, &got)
+			want := testRoleAssignment
+			change(&want)
+			if got := roleAssignmentOf(got); got != want {
+				t.Errorf("state = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRoleAssignmentResourceUpdateInPlace(t *testing.T) {
+	api := &fakeRoleAssignment{ra: testRoleAssignment}
+	r := &roleAssignmentResource{}
+	s := configureResource(t, r, newTestProviderData(t, api.handler(t)))
+
+	state := testRoleAssignmentModel(testRoleAssignment)
+	changed := testRoleAssignment
+	changed.ShopID = "s-2"
+	changed.SoDClassID = "sod-2"
+	changed.OrderFor = "Nur interne Mitarbeiter"
+	changed.ApprovalFlow = "Vorgesetzter"
+	changed.Description = "Schreiber"
+	changed.CanFachrolle = true
+	plan := testRoleAssignmentModel(changed)
+
+	req := resource.UpdateRequest{State: testState(t, s, &state), Plan: testPlan(t, s, &plan)}
+	resp := resource.UpdateResponse{State: req.State}
+	r.Update(t.Context(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", resp.Diagnostics)
+	}
+	if api.updates != 1 || api.ra != changed {
+		t.Errorf("%d updates, role assignment %+v, want %+v", api.updates, api.ra, changed)
+	}
+	var got roleAssignmentResourceModel
+	getState(t, resp.State, &got)
+	if got := roleAssignmentOf(got); got != changed {
+		t.Errorf("state = %+v, want %+v", got, changed)
+	}
+}
+
+// The module, group and application of a role assignment cannot be updated,
+// so changing them replaces it. All other attributes are updated in place.
+func TestRoleAssignmentResourceRequiresReplace(t *testing.T) {
+	r := &roleAssignmentResource{}
+	s := configureResource(t, r, &uamoimProviderData{})
+
+	tests := map[string]bool{
+		"application_name": true,
+		"module_id":        true,
+		"group_id":         true,
+		"shop_id":          false,
+		"sod_class_id":     false,
+		"order_for":        false,
+		"approval_flow":    false,
+		"description":      false,
+	}
+	for name, want := range tests {
+		if got := requiresReplace(t, s, name, "old", "new"); got != want {
+			t.Errorf("%s: requires replace = %t, want %t", name, got, want)
+		}
+	}
+	if a, ok := s.Attributes["can_fachrolle"].(schema.BoolAttribute); !ok || len(a.PlanModifiers) != 0 {
+		t.Error("can_fachrolle: expected an in-place update")
+	}
+}
+
+// requiresReplace runs the plan modifiers of the string attribute name of s
+// for a configured change from from to to.
+func requiresReplace(t *testing.T, s schema.Schema, name, from, to string) bool {
+	t.Helper()
+	a, ok := s.Attributes[name].(schema.StringAttribute)
+	if !ok {
+		t.Fatalf("%s is not a string attribute", name)
+	}
+	state := tfsdk.State{Schema: s, Raw: nullValue(s)}
+	state.SetAttribute(t.Context(), path.Root(name), from)
+	req := planmodifier.StringRequest{
+		Path:        path.Root(name),
+		ConfigValue: types.StringValue(to),
+		PlanValue:   types.StringValue(to),
+		StateValue:  types.StringValue(from),
+		Plan:        tfsdk.Plan{Schema: s, Raw: state.Raw},
+		State:       state,
+	}
+	resp := planmodifier.StringResponse{PlanValue: req.PlanValue}
+	for _, m := range a.PlanModifiers {
+		m.PlanModifyString(t.Context(), req, &resp)
+	}
+	return resp.RequiresReplace
+}
+
+func TestRoleAssignmentResourceImport(t *testing.T) {
+	api := &fakeRoleAssignment{ra: testRoleAssignment}
+	r := &roleAssignmentResource{}
+	s := configureResource(t, r, newTestProviderData(t, api.handler(t)))
+	ctx := t.Context()
+
+	importResp := resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "ra-1"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+
+	// Terraform reads the imported resource right away.
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	var got roleAssignmentResourceModel
+	getState(t, readResp.State, &got)
+	if got := roleAssignmentOf(got); got != testRoleAssignment {
+		t.Errorf("state = %+v, want %+v", got, testRoleAssignment)
+	}
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &shopByNameDataSource{}
+	_ datasource.DataSourceWithConfigure = &shopByNameDataSource{}
+)
+
+type shopByNameDataSourceModel struct {
+	ShopName    types.String `tfsdk:"shop_name"`
+	ModuleID    types.String `tfsdk:"module_id"`
+	ID          types.String `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+	Status      types.String `tfsdk:"status"`
+}
+
+func NewShopByNameDataSource() datasource.DataSource {
+	return &shopByNameDataSource{}
+}
+
+type shopByNameDataSource struct {
+	client *uamclient.Client
+}
+
+func (d *shopByNameDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = data.Client
+}
+
+func (d *shopByNameDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shop_by_name"
+}
+
+func (d *shopByNameDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single shop (catalog) entry by its name.",
+		Attributes: map[string]schema.Attribute{
+			"shop_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The exact name of the shop.",
+			},
+			"module_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Restricts the lookup to shops of the module with this ID.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the shop.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "The description of the shop.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the shop.",
+			},
+		},
+	}
+}
+
+func (d *shopByNameDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state shopByNameDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	shops, err := d.client.Shops.List(ctx, &uamclient.ShopListOptions{
+		ModuleID: state.ModuleID.ValueString(),
+		Name:     state.ShopName.ValueString(),
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Unable to Read uamoim Shops",
+			"Could not list shops named "+state.ShopName.ValueString(),
+			err, nil,
+		)
+		return
+	}
+
+	shop, diags := lookupByName(shops, state.ShopName.ValueString(),
+		func(s uamclient.Shop) string { return s.Name },
+		func(s uamclient.Shop) string { return s.ID },
+		path.Root("shop_name"), "Shop")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(shop.ID)
+	state.ModuleID = types.StringValue(shop.ModuleID)
+	state.Description = types.StringValue(shop.Description)
+	state.Status = types.StringValue(shop.Status)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestShopByNameDataSourceRead(t *testing.T) {
+	data := newTestProviderData(t, testShopsHandler(t))
+	tests := map[string]struct {
+		shopName, moduleID types.String
+		want, wantErr      string
+	}{
+		"in module":          {shopName: types.StringValue("OSKA Leser"), moduleID: types.StringValue("m-3"), want: "s-4"},
+		"unique":             {shopName: types.StringValue("PRISMA Leser"), moduleID: types.StringNull(), want: "s-3"},
+		"not in module":      {shopName: types.StringValue("PRISMA Leser"), moduleID: types.StringValue("m-1"), wantErr: "Shop Not Found"},
+		"prefix of another":  {shopName: types.StringValue("OSKA"), moduleID: types.StringNull(), wantErr: "Shop Not Found"},
+		"in several modules": {shopName: types.StringValue("OSKA Leser"), moduleID: types.StringNull(), wantErr: "Ambiguous Shop Name"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := readDataSource(t, NewShopByNameDataSource(), data, &shopByNameDataSourceModel{
+				ShopName:    tt.shopName,
+				ModuleID:    tt.moduleID,
+				ID:          types.StringNull(),
+				Description: types.StringNull(),
+				Status:      types.StringNull(),
+			})
+			if tt.wantErr != "" {
+				if got := attributeError(resp.Diagnostics, path.Root("shop_name")); got != tt.wantErr {
+					t.Errorf("error on shop_name = %q, want %q (%v)", got, tt.wantErr, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read: %v", resp.Diagnostics)
+			}
+			var got shopByNameDataSourceModel
+			getState(t, resp.State, &got)
+			if got.ID.ValueString() != tt.want {
+				t.Errorf("id = %s, want %s", got.ID, tt.want)
+			}
+		})
+	}
+}
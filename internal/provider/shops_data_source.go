@@ -2,24 +2,155 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &shopsDataSource{}
+	_ datasource.DataSourceWithConfigure = &shopsDataSource{}
 )
 
+type shopsDataSourceModel struct {
+	ModuleID   types.String            `tfsdk:"module_id"`
+	NamePrefix types.String            `tfsdk:"name_prefix"`
+	Shops      []shopsModel            `tfsdk:"shops"`
+	ByName     map[string]types.String `tfsdk:"by_name"`
+	ByID       map[string]types.String `tfsdk:"by_id"`
+}
+
+// shopsAPIFields maps API query parameters to the attributes they come from.
+var shopsAPIFields = rootPaths("module_id", "name_prefix")
+
+type shopsModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	ModuleID    types.String `tfsdk:"module_id"`
+	Description types.String `tfsdk:"description"`
+	Status      types.String `tfsdk:"status"`
+}
+
 func NewShopsDataSource() datasource.DataSource {
 	return &shopsDataSource{}
 }
 
-type shopsDataSource struct{}
+type shopsDataSource struct {
+	client *uamclient.Client
+}
+
+func (d *shopsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = data.Client
+}
 
 func (d *shopsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_shops"
 }
 
 func (d *shopsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	resp.Schema = schema.Schema{}
+	resp.Schema = schema.Schema{
+		Description: "Lists shop (catalog) entries.",
+		Attributes: map[string]schema.Attribute{
+			"module_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return shops of the module with this ID.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return shops whose name starts with this prefix.",
+			},
+			"shops": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"module_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"by_name": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "A map of shop IDs by their name. Of several shops with the same name, it holds the first one listed.",
+			},
+			"by_id": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "A map of shop names by their ID.",
+			},
+		},
+	}
 }
 
 func (d *shopsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state shopsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Shops = []shopsModel{}
+	byName := newNameMap()
+	byID := map[string]types.String{}
+	for shop, err := range d.client.Shops.All(ctx, &uamclient.ShopListOptions{
+		ModuleID:   state.ModuleID.ValueString(),
+		NamePrefix: state.NamePrefix.ValueString(),
+	}) {
+		if err != nil {
+			addAPIError(
+				&resp.Diagnostics,
+				"Unable to Read uamoim Shops",
+				"Could not list shops",
+				err, shopsAPIFields,
+			)
+			return
+		}
+		state.Shops = append(state.Shops, shopsModel{
+			ID:          types.StringValue(shop.ID),
+			Name:        types.StringValue(shop.Name),
+			ModuleID:    types.StringValue(shop.ModuleID),
+			Description: types.StringValue(shop.Description),
+			Status:      types.StringValue(shop.Status),
+		})
+		byID[shop.ID] = types.StringValue(shop.Name)
+		byName.add(shop.Name, shop.ID)
+	}
+	state.ByName = byName.ids
+	byName.warnDuplicates(&resp.Diagnostics, "Shop", "shops", "by_id or the shops list")
+	state.ByID = byID
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+var testShops = []uamclient.Shop{
+	{ID: "s-1", Name: "OSKA Leser", ModuleID: "m-1", Description: "read", Status: "active"},
+	{ID: "s-2", Name: "OSKA Schreiber", ModuleID: "m-1", Status: "active"},
+	{ID: "s-3", Name: "PRISMA Leser", ModuleID: "m-2", Status: "inactive"},
+	{ID: "s-4", Name: "OSKA Leser", ModuleID: "m-3", Status: "active"},
+}
+
+// testShopsHandler serves testShops, filtered like the API.
+func testShopsHandler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/shops", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		items := []uamclient.Shop{}
+		for _, shop := range testShops {
+			if (q.Get("module_id") == "" || shop.ModuleID == q.Get("module_id")) &&
+				strings.HasPrefix(shop.Name, q.Get("name_prefix")) {
+				items = append(items, shop)
+			}
+		}
+		writeTestJSON(t, w, http.StatusOK, map[string]any{"items": items})
+	})
+	return mux
+}
+
+// readShops reads the uamoim_shops data source with the given filters.
+func readShops(t *testing.T, moduleID, namePrefix types.String) (shopsDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+	d := NewShopsDataSource()
+	s := configureDataSource(t, d, newTestProviderData(t, testShopsHandler(t)))
+
+	config := testConfig(t, s, &shopsDataSourceModel{ModuleID: moduleID, NamePrefix: namePrefix})
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	d.Read(t.Context(), datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	var state shopsDataSourceModel
+	getState(t, resp.State, &state)
+	return state, resp.Diagnostics
+}
+
+func TestShopsDataSourceFilters(t *testing.T) {
+	tests := []struct {
+		moduleID, namePrefix types.String
+		want                 []string
+	}{
+		{types.StringValue("m-1"), types.StringNull(), []string{"s-1", "s-2"}},
+		{types.StringNull(), types.StringValue("OSKA "), []string{"s-1", "s-2", "s-4"}},
+		{types.StringValue("m-1"), types.StringValue("OSKA S"), []string{"s-2"}},
+		{types.StringValue("m-9"), types.StringNull(), []string{}},
+	}
+	for _, tt := range tests {
+		state, _ := readShops(t, tt.moduleID, tt.namePrefix)
+		ids := []string{}
+		for _, shop := range state.Shops {
+			ids = append(ids, shop.ID.ValueString())
+		}
+		if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("module_id %s, name_prefix %s: got shops %v, want %v", tt.moduleID, tt.namePrefix, ids, tt.want)
+		}
+		if len(state.ByID) != len(tt.want) {
+			t.Errorf("module_id %s, name_prefix %s: by_id = %v", tt.moduleID, tt.namePrefix, state.ByID)
+		}
+	}
+}
+
+func TestShopsDataSourceMaps(t *testing.T) {
+	state, diags := readShops(t, types.StringValue("m-1"), types.StringNull())
+	if diags.WarningsCount() != 0 {
+		t.Errorf("unexpected warnings: %v", diags)
+	}
+	shop := state.Shops[0]
+	if shop.Name.ValueString() != "OSKA Leser" || shop.ModuleID.ValueString() != "m-1" ||
+		shop.Description.ValueString() != "read" || shop.Status.ValueString() != "active" {
+		t.Errorf("shops[0] = %+v", shop)
+	}
+
+	wantByName := map[string]string{"OSKA Leser": "s-1", "OSKA Schreiber": "s-2"}
+	wantByID := map[string]string{"s-1": "OSKA Leser", "s-2": "OSKA Schreiber"}
+	for name, id := range wantByName {
+		if got := state.ByName[name]; got.ValueString() != id {
+			t.Errorf("by_name[%s] = %s, want %s", name, got, id)
+		}
+	}
+	for id, name := range wantByID {
+		if got := state.ByID[id]; got.ValueString() != name {
+			t.Errorf("by_id[%s] = %s, want %s", id, got, name)
+		}
+	}
+	if len(state.ByName) != len(wantByName) || len(state.ByID) != len(wantByID) {
+		t.Errorf("by_name = %v, by_id = %v", state.ByName, state.ByID)
+	}
+}
+
+func TestShopsDataSourceDuplicateNames(t *testing.T) {
+	state, diags := readShops(t, types.StringNull(), types.StringValue("OSKA Leser"))
+
+	// Both shops are listed, but by_name keeps the first.
+	if len(state.Shops) != 2 || len(state.ByID) != 2 {
+		t.Errorf("shops = %v, by_id = %v", state.Shops, state.ByID)
+	}
+	if got := state.ByName["OSKA Leser"]; len(state.ByName) != 1 || got.ValueString() != "s-1" {
+		t.Errorf("by_name = %v, want OSKA Leser = s-1", state.ByName)
+	}
+	if diags.WarningsCount() != 1 || !strings.Contains(diags.Warnings()[0].Detail(), `"OSKA Leser"`) {
+		t.Errorf("expected a warning about the duplicate name, got %v", diags)
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &sodClassByNameDataSource{}
+	_ datasource.DataSourceWithConfigure = &sodClassByNameDataSource{}
+)
+
+type sodClassByNameDataSourceModel struct {
+	SoDClassName types.String `tfsdk:"sod_class_name"`
+	ID           types.String `tfsdk:"id"`
+	RiskLevel    types.String `tfsdk:"risk_level"`
+	Description  types.String `tfsdk:"description"`
+}
+
+func NewSoDClassByNameDataSource() datasource.DataSource {
+	return &sodClassByNameDataSource{}
+}
+
+type sodClassByNameDataSource struct {
+	client *uamclient.Client
+}
+
+func (d *sodClassByNameDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = data.Client
+}
+
+func (d *sodClassByNameDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sod_class_by_name"
+}
+
+func (d *sodClassByNameDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single segregation of duties (SoD) class by its name.",
+		Attributes: map[string]schema.Attribute{
+			"sod_class_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The exact name of the SoD class, e.g. \"Keine SoD Relevanz\".",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the SoD class.",
+			},
+			"risk_level": schema.StringAttribute{
+				Computed:    true,
+				Description: "The risk level of the SoD class.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "The description of the SoD class.",
+			},
+		},
+	}
+}
+
+func (d *sodClassByNameDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state sodClassByNameDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sodClasses, err := d.client.SoDClasses.List(ctx, &uamclient.SoDClassListOptions{
+		Name: state.SoDClassName.ValueString(),
+	})
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Unable to Read uamoim SoD Classes",
+			"Could not list SoD classes named "+state.SoDClassName.ValueString(),
+			err, nil,
+		)
+		return
+	}
+
+	sodClass, diags := lookupByName(sodClasses, state.SoDClassName.ValueString(),
+		func(s uamclient.SoDClass) string { return s.Name },
+		func(s uamclient.SoDClass) string { return s.ID },
+		path.Root("sod_class_name"), "SoD Class")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(sodClass.ID)
+	state.RiskLevel = types.StringValue(sodClass.RiskLevel)
+	state.Description = types.StringValue(sodClass.Description)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
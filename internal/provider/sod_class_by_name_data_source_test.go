@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+func TestSoDClassByNameDataSourceRead(t *testing.T) {
+	// The API matches names loosely, so all SoD classes are served.
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/sod-classes", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(t, w, http.StatusOK, map[string]any{"items": []uamclient.SoDClass{
+			{ID: "sod-1", Name: "Keine SoD Relevanz", RiskLevel: "none"},
+			{ID: "sod-2", Name: "Kritisch", RiskLevel: "high"},
+			{ID: "sod-3", Name: "Kritisch", RiskLevel: "high"},
+		}})
+	})
+	data := newTestProviderData(t, mux)
+	tests := map[string]struct{ want, wantErr string }{
+		"Keine SoD Relevanz": {want: "sod-1"},
+		"Keine SoD":          {wantErr: "SoD Class Not Found"},
+		"Kritisch":           {wantErr: "Ambiguous SoD Class Name"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := readDataSource(t, NewSoDClassByNameDataSource(), data, &sodClassByNameDataSourceModel{
+				SoDClassName: types.StringValue(name),
+				ID:           types.StringNull(),
+				RiskLevel:    types.StringNull(),
+				Description:  types.StringNull(),
+			})
+			if tt.wantErr != "" {
+				if got := attributeError(resp.Diagnostics, path.Root("sod_class_name")); got != tt.wantErr {
+					t.Errorf("error on sod_class_name = %q, want %q (%v)", got, tt.wantErr, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read: %v", resp.Diagnostics)
+			}
+			var got sodClassByNameDataSourceModel
+			getState(t, resp.State, &got)
+			if got.ID.ValueString() != tt.want || got.RiskLevel.ValueString() != "none" {
+				t.Errorf("got SoD class %s (%s), want %s", got.ID, got.RiskLevel, tt.want)
+			}
+		})
+	}
+}
@@ -2,24 +2,120 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &sodsDataSource{}
+	_ datasource.DataSourceWithConfigure = &sodsDataSource{}
 )
 
+type sodsDataSourceModel struct {
+	SoDClasses []sodClassesModel       `tfsdk:"sod_classes"`
+	ByName     map[string]types.String `tfsdk:"by_name"`
+}
+
+type sodClassesModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	RiskLevel   types.String `tfsdk:"risk_level"`
+	Description types.String `tfsdk:"description"`
+}
+
 func NewSODsDataSource() datasource.DataSource {
 	return &sodsDataSource{}
 }
 
-type sodsDataSource struct{}
+type sodsDataSource struct {
+	client *uamclient.Client
+}
+
+func (d *sodsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*uamoimProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.uamoimProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = data.Client
+}
 
 func (d *sodsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_sods"
 }
 
 func (d *sodsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	resp.Schema = schema.Schema{}
+	resp.Schema = schema.Schema{
+		Description: "Lists all segregation of duties (SoD) classes.",
+		Attributes: map[string]schema.Attribute{
+			"sod_classes": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"risk_level": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"by_name": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "A map of SoD class IDs by their name. Of several SoD classes with the same name, it holds the first one listed.",
+			},
+		},
+	}
 }
 
 func (d *sodsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state sodsDataSourceModel
+	state.SoDClasses = []sodClassesModel{}
+	byName := newNameMap()
+	for sodClass, err := range d.client.SoDClasses.All(ctx, nil) {
+		if err != nil {
+			addAPIError(
+				&resp.Diagnostics,
+				"Unable to Read uamoim SoD Classes",
+				"Could not list SoD classes",
+				err, nil,
+			)
+			return
+		}
+		state.SoDClasses = append(state.SoDClasses, sodClassesModel{
+			ID:          types.StringValue(sodClass.ID),
+			Name:        types.StringValue(sodClass.Name),
+			RiskLevel:   types.StringValue(sodClass.RiskLevel),
+			Description: types.StringValue(sodClass.Description),
+		})
+		byName.add(sodClass.Name, sodClass.ID)
+	}
+	state.ByName = byName.ids
+	byName.warnDuplicates(&resp.Diagnostics, "SoD Class", "SoD classes", "the sod_classes list")
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
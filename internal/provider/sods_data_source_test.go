@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"terraform-provider-uamoim/internal/uamclient"
+)
+
+func TestSODsDataSourceRead(t *testing.T) {
+	sodClasses := []uamclient.SoDClass{
+		{ID: "sod-1", Name: "Keine SoD Relevanz", RiskLevel: "none", Description: "No segregation of duties needed."},
+		{ID: "sod-2", Name: "Kritisch", RiskLevel: "high", Description: "Must not be combined with approval roles."},
+		{ID: "sod-3", Name: "Keine SoD Relevanz", RiskLevel: "none"},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/sod-classes", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(t, w, http.StatusOK, map[string]any{"items": sodClasses})
+	})
+	d := NewSODsDataSource()
+	s := configureDataSource(t, d, newTestProviderData(t, mux))
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: nullValue(s)}}
+	d.Read(t.Context(), datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: nullValue(s)}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), `"Keine SoD Relevanz"`) {
+		t.Errorf("expected a warning about the duplicate name, got %v", resp.Diagnostics)
+	}
+
+	var state sodsDataSourceModel
+	getState(t, resp.State, &state)
+	if len(state.SoDClasses) != len(sodClasses) {
+		t.Fatalf("got %d SoD classes, want %d", len(state.SoDClasses), len(sodClasses))
+	}
+	for i, want := range sodClasses {
+		got := state.SoDClasses[i]
+		if got.ID.ValueString() != want.ID || got.Name.ValueString() != want.Name ||
+			got.RiskLevel.ValueString() != want.RiskLevel || got.Description.ValueString() != want.Description {
+			t.Errorf("sod_classes[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+	// All SoD classes are listed, but by_name keeps the first of a name.
+	if len(state.ByName) != 2 || state.ByName["Keine SoD Relevanz"].ValueString() != "sod-1" ||
+		state.ByName["Kritisch"].ValueString() != "sod-2" {
+		t.Errorf("by_name = %v", state.ByName)
+	}
+}
@@ -0,0 +1,192 @@
+package uamclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redacted replaces the values of sensitive request fields in the audit log.
+const redacted = "REDACTED"
+
+// sensitiveFields are the substrings of the request field names whose values
+// are redacted, compared in lower case.
+var sensitiveFields = []string{"password", "secret", "token", "authorization"}
+
+// auditLog writes one JSON line per request that may change an object.
+type auditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+	// prefix is the path of the API on the host, stripped from the object
+	// path.
+	prefix string
+}
+
+// auditEntry is a line of the audit log.
+type auditEntry struct {
+	Time            time.Time       `json:"time"`
+	Method          string          `json:"method"`
+	ObjectType      string          `json:"object_type"`
+	ObjectID        string          `json:"object_id,omitempty"`
+	Request         json.RawMessage `json:"request,omitempty"`
+	Status          int             `json:"status,omitempty"`
+	Error           string          `json:"error,omitempty"`
+	ChangeReference string          `json:"change_reference,omitempty"`
+	Resource        string          `json:"resource,omitempty"`
+	ResourceID      string          `json:"resource_id,omitempty"`
+}
+
+type resourceKey struct{}
+
+// resource identifies the Terraform resource instance requests are made for.
+type resource struct {
+	typ, id string
+}
+
+// WithResource returns a copy of ctx that names the Terraform resource the
+// requests made with it are made for by its type and ID. The ID is empty
+// for a resource that is being created. Terraform does not pass the address
+// of a resource in the configuration to providers, so the type and ID
+// identify it in the audit log, which records them with every request that
+// may change an object.
+func WithResource(ctx context.Context, resourceType, id string) context.Context {
+	return context.WithValue(ctx, resourceKey{}, resource{typ: resourceType, id: id})
+}
+
+func newAuditLog(w io.Writer, baseURL *url.URL) *auditLog {
+	if w == nil {
+		return nil
+	}
+	return &auditLog{w: w, prefix: strings.TrimSuffix(baseURL.EscapedPath(), "/")}
+}
+
+// record logs a request of method to u with the request body in and its
+// outcome. The object ID of a create is taken from the response body.
+func (a *auditLog) record(ctx context.Context, method string, u *url.URL, in any, status int, body []byte, err error) {
+	entry := auditEntry{
+		Time:            time.Now().UTC(),
+		Method:          method,
+		Status:          status,
+		ChangeReference: changeReference(ctx),
+	}
+	entry.ObjectType, entry.ObjectID = a.object(u)
+	if entry.ObjectID == "" && err == nil {
+		entry.ObjectID = responseID(body)
+	}
+	if in != nil {
+		entry.Request = redactJSON(in)
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if r, ok := ctx.Value(resourceKey{}).(resource); ok {
+		entry.Resource, entry.ResourceID = r.typ, r.id
+	}
+
+	line, mErr := json.Marshal(entry)
+	if mErr != nil {
+		tflog.Error(ctx, "Could not encode uamoim audit log entry", map[string]any{"error": mErr.Error()})
+		return
+	}
+	line = append(line, '\n')
+
+	// A single write per line keeps lines whole, also for other processes
+	// appending to the same file.
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, wErr := a.w.Write(line); wErr != nil {
+		tflog.Error(ctx, "Could not write uamoim audit log entry", map[string]any{"error": wErr.Error()})
+	}
+}
+
+// object returns the object type and ID of u, e.g. "modules" and "m-1" for
+// /api/v1/modules/m-1, or "modules/bisos" and "m-1/XZ41234" for a BISO of a
+// module. The ID is empty for a collection.
+func (a *auditLog) object(u *url.URL) (string, string) {
+	p := strings.TrimPrefix(u.EscapedPath(), a.prefix)
+	p = strings.TrimPrefix(strings.TrimPrefix(p, apiPrefix), "/")
+	var types, ids []string
+	for i, segment := range strings.Split(p, "/") {
+		if s, err := url.PathUnescape(segment); err == nil {
+			segment = s
+		}
+		if i%2 == 0 {
+			types = append(types, segment)
+		} else {
+			ids = append(ids, segment)
+		}
+	}
+	if len(ids) < len(types) {
+		// The ID of the innermost object is not part of the path.
+		ids = nil
+	}
+	return strings.Join(types, "/"), strings.Join(ids, "/")
+}
+
+// responseID returns the "id" of a JSON object response, or "".
+func responseID(body []byte) string {
+	var out struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if json.Unmarshal(body, &out) != nil || len(out.ID) == 0 {
+		return ""
+	}
+	var id string
+	if json.Unmarshal(out.ID, &id) == nil {
+		return id
+	}
+	// A numeric ID.
+	return string(out.ID)
+}
+
+// redactJSON returns the JSON encoding of in with the values of sensitive
+// fields replaced.
+func redactJSON(in any) json.RawMessage {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil
+	}
+	b, err = json.Marshal(redact(v))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, fv := range v {
+			if isSensitive(k) {
+				v[k] = redacted
+			} else {
+				v[k] = redact(fv)
+			}
+		}
+	case []any:
+		for i, ev := range v {
+			v[i] = redact(ev)
+		}
+	}
+	return v
+}
+
+func isSensitive(field string) bool {
+	field = strings.ToLower(field)
+	for _, s := range sensitiveFields {
+		if strings.Contains(field, s) {
+			return true
+		}
+	}
+	return false
+}
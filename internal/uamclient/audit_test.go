@@ -0,0 +1,146 @@
+package uamclient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// lockedBuffer is a bytes.Buffer that detects unserialized writes.
+type lockedBuffer struct {
+	buf     bytes.Buffer
+	writing sync.Mutex
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	if !b.writing.TryLock() {
+		return 0, fmt.Errorf("concurrent write")
+	}
+	defer b.writing.Unlock()
+	return b.buf.Write(p)
+}
+
+func TestClientWritesAuditLog(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	})
+	mux.HandleFunc("POST /api/v1/modules", func(w http.ResponseWriter, r *http.Request) {
+		var in ModuleCreate
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		writeJSON(t, w, http.StatusCreated, Module{ID: "m-" + in.Name, Name: in.Name})
+	})
+	mux.HandleFunc("GET /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, Module{ID: r.PathValue("id")})
+	})
+	mux.HandleFunc("DELETE /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "module not found"})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	var log lockedBuffer
+	c, err := NewClient(Config{Host: srv.URL, Username: "user", Password: "secret", AuditLog: &log})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := WithChangeReference(t.Context(), "UGITLAB-3")
+	createCtx := WithResource(ctx, "uamoim_module", "")
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Modules.Create(createCtx, ModuleCreate{ApplicationName: "app", Name: fmt.Sprint(i)}); err != nil {
+				t.Errorf("Create: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := c.Modules.Get(ctx, "m-0"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := c.Modules.Delete(WithResource(ctx, "uamoim_module", "m 1"), "m 1"); !IsNotFound(err) {
+		t.Fatalf("Delete error = %v, want not found", err)
+	}
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(&log.buf)
+	for scanner.Scan() {
+		var e auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != n+1 {
+		t.Fatalf("got %d audit entries, want %d", len(entries), n+1)
+	}
+
+	created := entries[0]
+	if created.Method != http.MethodPost || created.ObjectType != "modules" || created.ObjectID == "" ||
+		created.Status != http.StatusCreated || created.ChangeReference != "UGITLAB-3" ||
+		created.Resource != "uamoim_module" || created.ResourceID != "" || created.Time.IsZero() {
+		t.Errorf("unexpected create entry %+v", created)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(created.Request, &body); err != nil || body["application_name"] != "app" {
+		t.Errorf("request = %s, %v", created.Request, err)
+	}
+
+	deleted := entries[n]
+	if deleted.Method != http.MethodDelete || deleted.ObjectType != "modules" || deleted.ObjectID != "m 1" ||
+		deleted.Status != http.StatusNotFound || deleted.Error == "" ||
+		deleted.Resource != "uamoim_module" || deleted.ResourceID != "m 1" {
+		t.Errorf("unexpected delete entry %+v", deleted)
+	}
+}
+
+func TestAuditLogObject(t *testing.T) {
+	a := newAuditLog(&bytes.Buffer{}, mustParseURL(t, "https://oim.example.com/uam/"))
+	tests := map[string][2]string{
+		"https://oim.example.com/uam/api/v1/modules":                {"modules", ""},
+		"https://oim.example.com/uam/api/v1/modules/m%2F1":          {"modules", "m/1"},
+		"https://oim.example.com/uam/api/v1/modules/m-1/bisos/XZ41": {"modules/bisos", "m-1/XZ41"},
+		"https://oim.example.com/uam/orders/7":                      {"orders", "7"},
+	}
+	for raw, want := range tests {
+		typ, id := a.object(mustParseURL(t, raw))
+		if typ != want[0] || id != want[1] {
+			t.Errorf("object(%s) = %q, %q, want %q, %q", raw, typ, id, want[0], want[1])
+		}
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	in := map[string]any{
+		"name":          "svc",
+		"password":      "secret",
+		"credentials":   []any{map[string]any{"ClientSecret": "s", "user": "u"}},
+		"refresh_token": "t",
+	}
+	got := string(redactJSON(in))
+	want := `{"credentials":[{"ClientSecret":"REDACTED","user":"u"}],"name":"svc","password":"REDACTED","refresh_token":"REDACTED"}`
+	if got != want {
+		t.Errorf("redactJSON = %s, want %s", got, want)
+	}
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
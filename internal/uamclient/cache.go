@@ -0,0 +1,129 @@
+package uamclient
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultCacheTTL is how long the provider caches read responses unless
+// configured otherwise.
+const DefaultCacheTTL = 5 * time.Minute
+
+// cache holds the bodies of successful GET responses for the lifetime of a
+// provider process, i.e. one Terraform run. Concurrent identical requests
+// are coalesced into one.
+//
+// Entries are grouped by collection, e.g. "modules" for everything below
+// /api/v1/modules. A mutating request invalidates its whole collection,
+// since e.g. creating a module also changes the result of listing modules
+// by name.
+type cache struct {
+	ttl   time.Duration
+	now   func() time.Time
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	// generations counts the invalidations per collection. A response is
+	// only stored if no mutation of its collection started while it was in
+	// flight.
+	generations map[string]uint64
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+func newCache(ttl time.Duration) *cache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &cache{
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     map[string]cacheEntry{},
+		generations: map[string]uint64{},
+	}
+}
+
+// get returns the cached body for u, calling fetch on a miss. Callers
+// requesting the same URL at the same time share a single fetch.
+func (c *cache) get(ctx context.Context, u *url.URL, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	key := u.String()
+	collection := cacheCollection(u.Path)
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && c.now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.body, nil
+	}
+	gen := c.generations[collection]
+	c.mu.Unlock()
+
+	// Requests started after a mutation must not join a fetch that may
+	// still return the old state.
+	flight := c.group.DoChan(strconv.FormatUint(gen, 10)+" "+key, func() (any, error) {
+		body, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		if c.generations[collection] == gen {
+			c.entries[key] = cacheEntry{body: body, expires: c.now().Add(c.ttl)}
+		}
+		c.mu.Unlock()
+		return body, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-flight:
+		// The fetch ran with the context of the first caller. If that one
+		// was cancelled, this caller still deserves an answer.
+		if res.Shared && isContextError(res.Err) && ctx.Err() == nil {
+			return fetch(ctx)
+		}
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		body, _ := res.Val.([]byte)
+		return body, nil
+	}
+}
+
+// invalidate drops all entries of the collection p belongs to.
+func (c *cache) invalidate(p string) {
+	collection := cacheCollection(p)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[collection]++
+	for key := range c.entries {
+		u, err := url.Parse(key)
+		if err != nil || cacheCollection(u.Path) == collection {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cacheCollection returns the collection of the API path p, e.g. "modules"
+// for /api/v1/modules/m-1/bisos/b-1 and "orders" for /orders/7.
+func cacheCollection(p string) string {
+	p = strings.TrimPrefix(p, apiPrefix)
+	p = strings.TrimPrefix(p, "/")
+	collection, _, _ := strings.Cut(p, "/")
+	return collection
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
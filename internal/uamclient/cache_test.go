@@ -0,0 +1,160 @@
+package uamclient
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// withCache enables the cache of a test client.
+func withCache(cfg *Config) {
+	cfg.CacheTTL = time.Minute
+}
+
+func TestCacheReusesReads(t *testing.T) {
+	var gets atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", signInHandler(t))
+	mux.HandleFunc("GET /api/v1/sod-classes", func(w http.ResponseWriter, r *http.Request) {
+		gets.Add(1)
+		writeJSON(t, w, http.StatusOK, page[SoDClass]{Items: []SoDClass{{ID: "sod-1", Name: r.URL.Query().Get("name")}}})
+	})
+	c := newTestClient(t, mux, withCache)
+	ctx := t.Context()
+
+	for range 3 {
+		got, err := c.SoDClasses.List(ctx, &SoDClassListOptions{Name: "Keine SoD Relevanz"})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(got) != 1 || got[0].Name != "Keine SoD Relevanz" {
+			t.Fatalf("unexpected SoD classes %+v", got)
+		}
+	}
+	if _, err := c.SoDClasses.List(ctx, &SoDClassListOptions{Name: "Hoch"}); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if n := gets.Load(); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+}
+
+func TestCacheExpires(t *testing.T) {
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", signInHandler(t))
+	mux.HandleFunc("GET /api/v1/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		writeJSON(t, w, http.StatusOK, Group{ID: r.PathValue("id")})
+	})
+	c := newTestClient(t, mux, withCache)
+	now := time.Now()
+	c.cache.now = func() time.Time { return now }
+	ctx := t.Context()
+
+	for _, advance := range []time.Duration{0, 30 * time.Second, time.Minute} {
+		now = now.Add(advance)
+		if _, err := c.Groups.Get(ctx, "g-1"); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+	if gets != 2 {
+		t.Errorf("got %d requests, want 2", gets)
+	}
+}
+
+func TestCacheCoalescesConcurrentReads(t *testing.T) {
+	var gets atomic.Int32
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", signInHandler(t))
+	mux.HandleFunc("GET /api/v1/groups", func(w http.ResponseWriter, r *http.Request) {
+		gets.Add(1)
+		<-release
+		writeJSON(t, w, http.StatusOK, page[Group]{Items: []Group{{ID: "g-1", Name: "KRN"}}})
+	})
+	c := newTestClient(t, mux, withCache)
+	// Sign in up front, so that the readers only race for the list.
+	if _, err := c.sessionToken(t.Context()); err != nil {
+		t.Fatalf("sign in: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			groups, err := c.Groups.List(t.Context(), &GroupListOptions{Name: "KRN"})
+			if err != nil || len(groups) != 1 {
+				t.Errorf("List = %+v, %v", groups, err)
+			}
+		})
+	}
+	// Give the readers time to join the first request.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := gets.Load(); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
+
+func TestCacheInvalidatedByMutation(t *testing.T) {
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", signInHandler(t))
+	mux.HandleFunc("GET /api/v1/modules", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		writeJSON(t, w, http.StatusOK, page[Module]{})
+	})
+	mux.HandleFunc("GET /api/v1/groups", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		writeJSON(t, w, http.StatusOK, page[Group]{})
+	})
+	mux.HandleFunc("DELETE /api/v1/modules/{id}/bisos/{biso}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	c := newTestClient(t, mux, withCache)
+	ctx := t.Context()
+
+	read := func() {
+		t.Helper()
+		if _, err := c.Modules.List(ctx, nil); err != nil {
+			t.Fatalf("List modules: %v", err)
+		}
+		if _, err := c.Groups.List(ctx, nil); err != nil {
+			t.Fatalf("List groups: %v", err)
+		}
+	}
+	read()
+	if err := c.ModuleBISOs.Unassign(ctx, "m-1", "b-1"); err != nil {
+		t.Fatalf("Unassign: %v", err)
+	}
+	read()
+
+	// The modules are listed again, the groups come from the cache.
+	if gets != 3 {
+		t.Errorf("got %d requests, want 3", gets)
+	}
+}
+
+func TestCacheSkipsErrors(t *testing.T) {
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", signInHandler(t))
+	mux.HandleFunc("GET /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "not found"})
+	})
+	c := newTestClient(t, mux, withCache)
+
+	for range 2 {
+		if _, err := c.Modules.Get(t.Context(), "m-1"); !IsNotFound(err) {
+			t.Fatalf("Get: got %v, want not found", err)
+		}
+	}
+	if gets != 2 {
+		t.Errorf("got %d requests, want 2", gets)
+	}
+}
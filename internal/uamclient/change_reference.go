@@ -0,0 +1,22 @@
+package uamclient
+
+import "context"
+
+// ChangeReferenceHeader is the request header that carries the change
+// reference, e.g. a ticket key, which the API records in its audit trail.
+const ChangeReferenceHeader = "X-Change-Reference"
+
+type changeReferenceKey struct{}
+
+// WithChangeReference returns a copy of ctx with the change reference ref.
+// Every request made with it that may change an object sends ref in the
+// ChangeReferenceHeader. An empty ref sends none.
+func WithChangeReference(ctx context.Context, ref string) context.Context {
+	return context.WithValue(ctx, changeReferenceKey{}, ref)
+}
+
+// changeReference returns the change reference of ctx, if there is one.
+func changeReference(ctx context.Context) string {
+	ref, _ := ctx.Value(changeReferenceKey{}).(string)
+	return ref
+}
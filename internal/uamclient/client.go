@@ -0,0 +1,439 @@
+// Package uamclient implements a client for the UAM/OIM REST API.
+//
+// A single Client is shared by every resource and data source of the
+// provider. The API objects are exposed through typed services, e.g.
+// client.Modules or client.RoleAssignments.
+package uamclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	// apiPrefix is the path prefix of the UAM/OIM object endpoints.
+	apiPrefix = "/api/v1"
+
+	defaultTimeout = 30 * time.Second
+
+	// DefaultUserAgent is sent unless Config.UserAgent is set.
+	DefaultUserAgent = "terraform-provider-uamoim"
+)
+
+// Config holds the settings used to construct a Client.
+type Config struct {
+	// Host is the base URL of the UAM/OIM API, e.g. https://oim.example.com.
+	Host string
+	// Username and Password are exchanged for a session token on the first
+	// request that needs one.
+	Username string
+	Password string
+	// OAuth authenticates with OAuth2 client credentials instead of a
+	// username and password. Optional.
+	OAuth *OAuthConfig
+	// HTTPClient overrides the HTTP client used for all requests. Optional.
+	// TLS is ignored if it is set.
+	HTTPClient *http.Client
+	// TLS configures server verification and client certificates.
+	TLS TLSConfig
+	// ProxyURL is the proxy for all requests. Without it, the proxy is taken
+	// from the HTTP_PROXY and HTTPS_PROXY environment variables.
+	ProxyURL string
+	// NoProxy lists the hosts that are reached without the proxy, in the
+	// format of the NO_PROXY environment variable, which it overrides.
+	NoProxy string
+	// Headers are added to every request. They cannot override the
+	// Accept, Content-Type and Authorization headers set by the client.
+	Headers map[string]string
+	// UserAgent identifies the client. Defaults to DefaultUserAgent.
+	UserAgent string
+	// Retry controls how failed requests are retried. The zero value
+	// disables retries.
+	Retry RetryPolicy
+	// MaxConcurrentRequests caps the number of requests in flight. Zero
+	// means unlimited.
+	MaxConcurrentRequests int
+	// RequestsPerSecond caps the request rate. Zero means unlimited.
+	RequestsPerSecond float64
+	// CacheTTL is how long successful GET responses are reused. Zero
+	// disables the cache.
+	CacheTTL time.Duration
+	// ReadOnly rejects every request that could change an object with
+	// ErrReadOnly before it is sent. Signing in and issuing tokens still
+	// work.
+	ReadOnly bool
+	// AuditLog receives a JSON line for every request that may change an
+	// object, with its redacted body and outcome. Optional. Writes are
+	// serialized, one Write per line.
+	AuditLog io.Writer
+}
+
+// ErrReadOnly is returned for requests that would change an object on a
+// read-only client.
+var ErrReadOnly = errors.New("client is read-only")
+
+// Client talks to the UAM/OIM API.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	username   string
+	password   string
+	headers    http.Header
+	retry      RetryPolicy
+	limiter    *limiter
+	cache      *cache
+	pageSize   int
+	readOnly   bool
+	audit      *auditLog
+
+	tokenMu sync.Mutex
+	token   string
+
+	// oauth is nil unless the client authenticates with OAuth2.
+	oauth *oauthSource
+
+	common service
+
+	Modules         *ModulesService
+	Groups          *GroupsService
+	Shops           *ShopsService
+	SoDClasses      *SoDClassesService
+	RoleAssignments *RoleAssignmentsService
+	ModuleBISOs     *ModuleBISOsService
+	Coffees         *CoffeesService
+	Orders          *OrdersService
+	Tokens          *TokensService
+	Server          *ServerService
+}
+
+// service is embedded by all typed services to reach the shared client.
+type service struct {
+	client *Client
+}
+
+// NewClient returns a Client for the given configuration.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("host must not be empty")
+	}
+	baseURL, err := url.Parse(strings.TrimSuffix(cfg.Host, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid host %q: %w", cfg.Host, err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid host %q: scheme must be http or https", cfg.Host)
+	}
+	// JoinPath only returns absolute paths for an absolute base path.
+	if baseURL.Path == "" {
+		baseURL.Path = "/"
+	}
+
+	if cfg.Retry.MaxRetries < 0 || cfg.Retry.MinWait < 0 || cfg.Retry.MinWait > cfg.Retry.MaxWait {
+		return nil, fmt.Errorf("invalid retry policy: need 0 <= MaxRetries and 0 <= MinWait <= MaxWait, got %+v", cfg.Retry)
+	}
+
+	if cfg.MaxConcurrentRequests < 0 || cfg.RequestsPerSecond < 0 {
+		return nil, errors.New("MaxConcurrentRequests and RequestsPerSecond must not be negative")
+	}
+
+	if cfg.CacheTTL < 0 {
+		return nil, errors.New("CacheTTL must not be negative")
+	}
+
+	var oauth *oauthSource
+	if cfg.OAuth != nil {
+		if cfg.Username != "" || cfg.Password != "" {
+			return nil, errors.New("use either username and password or OAuth2, not both")
+		}
+		if oauth, err = newOAuthSource(*cfg.OAuth); err != nil {
+			return nil, err
+		}
+	}
+
+	headers := http.Header{}
+	for name, value := range cfg.Headers {
+		if !httpguts.ValidHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) {
+			return nil, fmt.Errorf("invalid header %q", name)
+		}
+		headers.Set(name, value)
+	}
+	if headers.Get("User-Agent") == "" {
+		userAgent := cfg.UserAgent
+		if userAgent == "" {
+			userAgent = DefaultUserAgent
+		}
+		headers.Set("User-Agent", userAgent)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		transport, err := newTransport(cfg, baseURL)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = &http.Client{Transport: transport, Timeout: defaultTimeout}
+	}
+
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		username:   cfg.Username,
+		password:   cfg.Password,
+		oauth:      oauth,
+		headers:    headers,
+		retry:      cfg.Retry,
+		limiter:    newLimiter(cfg.MaxConcurrentRequests, cfg.RequestsPerSecond),
+		cache:      newCache(cfg.CacheTTL),
+		pageSize:   defaultPageSize,
+		readOnly:   cfg.ReadOnly,
+		audit:      newAuditLog(cfg.AuditLog, baseURL),
+	}
+	c.common.client = c
+	c.Modules = (*ModulesService)(&c.common)
+	c.Groups = (*GroupsService)(&c.common)
+	c.Shops = (*ShopsService)(&c.common)
+	c.SoDClasses = (*SoDClassesService)(&c.common)
+	c.RoleAssignments = (*RoleAssignmentsService)(&c.common)
+	c.ModuleBISOs = (*ModuleBISOsService)(&c.common)
+	c.Coffees = (*CoffeesService)(&c.common)
+	c.Orders = (*OrdersService)(&c.common)
+	c.Tokens = (*TokensService)(&c.common)
+	c.Server = (*ServerService)(&c.common)
+	return c, nil
+}
+
+// ReadOnly reports whether the client rejects requests that change objects.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+type signInRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type signInResponse struct {
+	Token string `json:"token"`
+	// ExpiresIn is the lifetime of the token in seconds, if the API tells.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// sessionToken returns the cached session token, signing in first if there
+// is none yet. Without credentials the client stays anonymous.
+func (c *Client) sessionToken(ctx context.Context) (string, error) {
+	if c.username == "" && c.password == "" {
+		return "", nil
+	}
+
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if c.token != "" {
+		return c.token, nil
+	}
+
+	var out signInResponse
+	in := signInRequest{Username: c.username, Password: c.password}
+	u := c.url("/signin", nil)
+	_, body, err := c.send(ctx, http.MethodPost, u, in, "")
+	if err == nil {
+		err = decode(http.MethodPost, u, body, &out)
+	}
+	if err != nil {
+		return "", fmt.Errorf("sign in: %w", err)
+	}
+	if out.Token == "" {
+		return "", errors.New("sign in: response did not contain a token")
+	}
+	c.token = out.Token
+	return c.token, nil
+}
+
+// do performs an authenticated request against p (relative to the host) and
+// decodes the JSON response into out unless out is nil.
+func (c *Client) do(ctx context.Context, method, p string, query url.Values, in, out any) error {
+	return c.doURL(ctx, method, c.url(p, query), in, out)
+}
+
+// doURL is like do, but for an absolute URL on the API host. GET requests
+// are answered from the cache if it is enabled; any other request
+// invalidates the cached responses of its collection and is recorded in the
+// audit log. A read-only client only performs GET requests.
+func (c *Client) doURL(ctx context.Context, method string, u *url.URL, in, out any) error {
+	if c.readOnly && method != http.MethodGet {
+		return fmt.Errorf("%s %s: %w", method, u.Path, ErrReadOnly)
+	}
+
+	var status int
+	var body []byte
+	var err error
+	switch {
+	case method == http.MethodGet && c.cache != nil:
+		body, err = c.cache.get(ctx, u, func(ctx context.Context) ([]byte, error) {
+			_, body, err := c.fetch(ctx, method, u, nil)
+			return body, err
+		})
+	case method == http.MethodGet:
+		_, body, err = c.fetch(ctx, method, u, nil)
+	default:
+		status, body, err = c.fetch(ctx, method, u, in)
+		// Invalidate even if the request failed, it may have been applied
+		// nevertheless.
+		if c.cache != nil {
+			c.cache.invalidate(u.Path)
+		}
+		if c.audit != nil {
+			c.audit.record(ctx, method, u, in, status, body, err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return decode(method, u, body, out)
+}
+
+// fetch performs an authenticated request and returns the response status
+// and body.
+func (c *Client) fetch(ctx context.Context, method string, u *url.URL, in any) (int, []byte, error) {
+	authorization, err := c.authorization(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	return c.send(ctx, method, u, in, authorization)
+}
+
+// authorization returns the Authorization header for API requests, which is
+// empty for an anonymous client.
+func (c *Client) authorization(ctx context.Context) (string, error) {
+	if c.oauth != nil {
+		token, err := c.oauthToken(ctx)
+		if err != nil {
+			return "", err
+		}
+		return token.authorization(), nil
+	}
+	return c.sessionToken(ctx)
+}
+
+// url returns the URL of p, relative to the host, with the given query.
+func (c *Client) url(p string, query url.Values) *url.URL {
+	u := c.baseURL.JoinPath(p)
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+	return u
+}
+
+// send performs a request, retrying it according to the retry policy, and
+// returns the status of the last response, if any, and the body of a
+// successful one.
+func (c *Client) send(ctx context.Context, method string, u *url.URL, in any, authorization string) (int, []byte, error) {
+	var payload []byte
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return 0, nil, fmt.Errorf("encode request body: %w", err)
+		}
+		payload = b
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(payload))
+		if err != nil {
+			return 0, nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		if in != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		if ref := changeReference(ctx); ref != "" && method != http.MethodGet {
+			req.Header.Set(ChangeReferenceHeader, ref)
+		}
+
+		res, resBody, err := c.roundTrip(req)
+		wait, retry := c.retry.retryWait(ctx, method, attempt, res, err)
+		if retry {
+			if err := sleep(ctx, wait); err != nil {
+				return 0, nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return res.StatusCode, nil, newAPIError(req, res, resBody)
+		}
+		return res.StatusCode, resBody, nil
+	}
+}
+
+// decode decodes the JSON response body of method u into out unless out is
+// nil or the body is empty.
+func decode(method string, u *url.URL, body []byte, out any) error {
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decode response of %s %s: %w", method, u.Path, err)
+	}
+	return nil
+}
+
+// roundTrip sends req with the configured headers, once the limiter allows
+// it, and reads the complete response body.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, []byte, error) {
+	for name, values := range c.headers {
+		if req.Header.Get(name) == "" {
+			req.Header[name] = slices.Clone(values)
+		}
+	}
+
+	release, err := c.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read response body: %w", err)
+	}
+	return res, body, nil
+}
+
+// objectPath joins the API prefix, the collection and an escaped object ID.
+func objectPath(collection string, id ...string) string {
+	p := apiPrefix + "/" + collection
+	for _, s := range id {
+		p += "/" + url.PathEscape(s)
+	}
+	return p
+}
+
+// setQuery adds key=value to query unless value is empty.
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
@@ -0,0 +1,270 @@
+package uamclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client talking to a test server serving handler.
+// It signs in with a username and password unless a configure function,
+// which is applied with the test server as Host, changes that.
+func newTestClient(t *testing.T, handler http.Handler, configure ...func(*Config)) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := Config{Host: srv.URL, Username: "user", Password: "secret"}
+	for _, f := range configure {
+		f(&cfg)
+	}
+	c, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encode response: %v", err)
+	}
+}
+
+func TestNewClientValidatesHost(t *testing.T) {
+	for _, host := range []string{"", "localhost:19090", "ftp://example.com"} {
+		if _, err := NewClient(Config{Host: host}); err == nil {
+			t.Errorf("NewClient(%q): expected error", host)
+		}
+	}
+}
+
+func TestClientSignsInOnce(t *testing.T) {
+	signIns := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		signIns++
+		var in signInRequest
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode sign in: %v", err)
+		}
+		if in.Username != "user" || in.Password != "secret" {
+			t.Errorf("unexpected credentials %+v", in)
+		}
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	})
+	mux.HandleFunc("GET /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "tok" {
+			t.Errorf("Authorization = %q, want %q", got, "tok")
+		}
+		writeJSON(t, w, http.StatusOK, Module{ID: r.PathValue("id"), Name: "PWS Blueprint"})
+	})
+	c := newTestClient(t, mux)
+
+	for range 3 {
+		m, err := c.Modules.Get(t.Context(), "m-1")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if m.ID != "m-1" || m.Name != "PWS Blueprint" {
+			t.Errorf("unexpected module %+v", m)
+		}
+	}
+	if signIns != 1 {
+		t.Errorf("signed in %d times, want 1", signIns)
+	}
+}
+
+func TestClientReturnsAPIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	})
+	mux.HandleFunc("GET /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "module not found"})
+	})
+	mux.HandleFunc("DELETE /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	c := newTestClient(t, mux)
+
+	_, err := c.Modules.Get(t.Context(), "missing")
+	if !IsNotFound(err) || !errors.Is(fmt.Errorf("wrapped: %w", err), ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if want := "GET /api/v1/modules/missing: status 404: module not found"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+
+	err = c.Modules.Delete(t.Context(), "m-1")
+	if err == nil || IsNotFound(err) {
+		t.Fatalf("expected server error, got %v", err)
+	}
+}
+
+func TestModulesListSendsFilters(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	})
+	mux.HandleFunc("GET /api/v1/modules", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("application_name") != "Application" || q.Get("name") != "OSKA" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		writeJSON(t, w, http.StatusOK, page[Module]{Items: []Module{{ID: "m-7", Name: "OSKA"}}})
+	})
+	c := newTestClient(t, mux)
+
+	modules, err := c.Modules.List(t.Context(), &ModuleListOptions{ApplicationName: "Application", Name: "OSKA"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(modules) != 1 || modules[0].ID != "m-7" {
+		t.Errorf("unexpected modules %+v", modules)
+	}
+}
+
+func TestClientAbortsOnContextCancel(t *testing.T) {
+	received := make(chan struct{})
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/signin" {
+			writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+			return
+		}
+		close(received)
+		<-r.Context().Done()
+	}))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		<-received
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Modules.Get(ctx, "m-1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not aborted after cancel")
+	}
+}
+
+func TestClientSendsHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	})
+	mux.HandleFunc("GET /api/v1/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		want := map[string]string{
+			"User-Agent":    "Terraform/1.9.0 terraform-provider-uamoim/1.2.3",
+			"X-Tenant":      "uis",
+			"Authorization": "tok",
+		}
+		for name, value := range want {
+			if got := r.Header.Get(name); got != value {
+				t.Errorf("%s = %q, want %q", name, got, value)
+			}
+		}
+		writeJSON(t, w, http.StatusOK, Group{ID: r.PathValue("id")})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{
+		Host:      srv.URL,
+		Username:  "user",
+		Password:  "secret",
+		UserAgent: "Terraform/1.9.0 terraform-provider-uamoim/1.2.3",
+		Headers:   map[string]string{"x-tenant": "uis", "Authorization": "ignored"},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := c.Groups.Get(t.Context(), "g-1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if _, err := NewClient(Config{Host: srv.URL, Headers: map[string]string{"X Tenant": "uis"}}); err == nil {
+		t.Error("NewClient with invalid header name: expected error")
+	}
+}
+
+func TestClientReadOnly(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	})
+	mux.HandleFunc("GET /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, Module{ID: r.PathValue("id")})
+	})
+	mux.HandleFunc("/api/v1/modules", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s on a read-only client", r.Method, r.URL.Path)
+	})
+	mux.HandleFunc("/api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s on a read-only client", r.Method, r.URL.Path)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{Host: srv.URL, Username: "user", Password: "secret", ReadOnly: true})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := t.Context()
+	if _, err := c.Modules.Get(ctx, "m-1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	_, createErr := c.Modules.Create(ctx, ModuleCreate{ApplicationName: "app", Name: "mod"})
+	_, updateErr := c.Modules.Update(ctx, "m-1", ModuleUpdate{})
+	deleteErr := c.Modules.Delete(ctx, "m-1")
+	for name, err := range map[string]error{"Create": createErr, "Update": updateErr, "Delete": deleteErr} {
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s error = %v, want ErrReadOnly", name, err)
+		}
+	}
+}
+
+func TestClientSendsChangeReference(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	})
+	mux.HandleFunc("GET /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(ChangeReferenceHeader); got != "" {
+			t.Errorf("GET sent %s %q", ChangeReferenceHeader, got)
+		}
+		writeJSON(t, w, http.StatusOK, Module{ID: r.PathValue("id")})
+	})
+	mux.HandleFunc("DELETE /api/v1/modules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(ChangeReferenceHeader); got != "UGITLAB-3" {
+			t.Errorf("%s = %q, want %q", ChangeReferenceHeader, got, "UGITLAB-3")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	c := newTestClient(t, mux)
+
+	ctx := WithChangeReference(t.Context(), "UGITLAB-3")
+	if _, err := c.Modules.Get(ctx, "m-1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := c.Modules.Delete(ctx, "m-1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+}
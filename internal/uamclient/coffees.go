@@ -0,0 +1,38 @@
+package uamclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// CoffeesService reads the coffee catalog of the local development API
+// (see docker_compose). It backs the uamoim_coffees data source.
+type CoffeesService service
+
+// Coffee is a coffee of the development API catalog.
+type Coffee struct {
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	Teaser      string       `json:"teaser"`
+	Description string       `json:"description"`
+	Price       float64      `json:"price"`
+	Image       string       `json:"image"`
+	Ingredient  []Ingredient `json:"ingredients"`
+}
+
+// Ingredient is a reference to an ingredient of a coffee.
+type Ingredient struct {
+	ID       int    `json:"ingredient_id"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	Unit     string `json:"unit"`
+}
+
+// List returns all coffees.
+func (s *CoffeesService) List(ctx context.Context) ([]Coffee, error) {
+	var out []Coffee
+	if err := s.client.do(ctx, http.MethodGet, "/coffees", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
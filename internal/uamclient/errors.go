@@ -0,0 +1,131 @@
+package uamclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody limits how much of a non-JSON error body ends up in messages.
+const maxErrorBody = 512
+
+// Sentinel errors matched, via errors.Is, by the APIError of a response with
+// the corresponding status code.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrValidation   = errors.New("validation failed")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// FieldError describes why the API rejected the value of a request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// APIError is returned for every response with a non-2xx status code.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+	// FieldErrors lists the rejected request fields of a validation error.
+	FieldErrors []FieldError
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if len(e.FieldErrors) > 0 {
+		fields := make([]string, 0, len(e.FieldErrors))
+		for _, fe := range e.FieldErrors {
+			fields = append(fields, fe.Field+": "+fe.Message)
+		}
+		msg += " (" + strings.Join(fields, "; ") + ")"
+	}
+	return fmt.Sprintf("%s %s: status %d: %s", e.Method, e.Path, e.StatusCode, msg)
+}
+
+// Is lets errors.Is match an APIError against the sentinel errors of this
+// package.
+func (e *APIError) Is(target error) bool {
+	return target != nil && e.kind() == target
+}
+
+// kind returns the sentinel error for the status code of e, or nil.
+func (e *APIError) kind() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrValidation
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// errorBody is the JSON error document returned by the UAM/OIM API. OAuth2
+// token endpoints use error and error_description (RFC 6749, section 5.2).
+type errorBody struct {
+	Message          string       `json:"message"`
+	Error            string       `json:"error"`
+	ErrorDescription string       `json:"error_description"`
+	Errors           []FieldError `json:"errors"`
+}
+
+func newAPIError(req *http.Request, res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		StatusCode: res.StatusCode,
+	}
+
+	var eb errorBody
+	if err := json.Unmarshal(body, &eb); err == nil {
+		apiErr.Message = eb.Message
+		if apiErr.Message == "" {
+			apiErr.Message = eb.Error
+			if eb.ErrorDescription != "" {
+				apiErr.Message += ": " + eb.ErrorDescription
+			}
+		}
+		apiErr.FieldErrors = eb.Errors
+		return apiErr
+	}
+
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > maxErrorBody {
+		msg = msg[:maxErrorBody] + "..."
+	}
+	apiErr.Message = msg
+	return apiErr
+}
+
+// IsNotFound reports whether err is an APIError for a missing object.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// FieldErrors returns the field errors of a validation error, or nil.
+func FieldErrors(err error) []FieldError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && errors.Is(apiErr, ErrValidation) {
+		return apiErr.FieldErrors
+	}
+	return nil
+}
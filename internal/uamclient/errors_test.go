@@ -0,0 +1,65 @@
+package uamclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorKinds(t *testing.T) {
+	tests := map[int]error{
+		http.StatusBadRequest:          ErrValidation,
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrForbidden,
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusConflict:            ErrConflict,
+		http.StatusUnprocessableEntity: ErrValidation,
+		http.StatusTooManyRequests:     ErrRateLimited,
+	}
+	all := []error{ErrNotFound, ErrConflict, ErrForbidden, ErrUnauthorized, ErrValidation, ErrRateLimited}
+
+	for status, want := range tests {
+		err := error(&APIError{StatusCode: status})
+		for _, sentinel := range all {
+			if got := errors.Is(err, sentinel); got != (sentinel == want) {
+				t.Errorf("status %d: errors.Is(err, %v) = %t", status, sentinel, got)
+			}
+		}
+	}
+
+	err := error(&APIError{StatusCode: http.StatusInternalServerError})
+	for _, sentinel := range all {
+		if errors.Is(err, sentinel) {
+			t.Errorf("status 500 matches %v", sentinel)
+		}
+	}
+}
+
+func TestValidationErrorFields(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	})
+	mux.HandleFunc("POST /api/v1/role-assignments", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusUnprocessableEntity, map[string]any{
+			"message": "validation failed",
+			"errors": []FieldError{
+				{Field: "approval_flow", Message: "unknown approval flow \"Chef\""},
+			},
+		})
+	})
+	c := newTestClient(t, mux)
+
+	_, err := c.RoleAssignments.Create(t.Context(), RoleAssignmentCreate{ApprovalFlow: "Chef"})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	fields := FieldErrors(err)
+	if len(fields) != 1 || fields[0].Field != "approval_flow" {
+		t.Errorf("unexpected field errors %+v", fields)
+	}
+	want := `POST /api/v1/role-assignments: status 422: validation failed (approval_flow: unknown approval flow "Chef")`
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
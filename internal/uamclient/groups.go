@@ -0,0 +1,47 @@
+package uamclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// GroupsService reads groups. Groups are maintained outside of UAM/OIM and
+// are therefore read-only.
+type GroupsService service
+
+// Group is a directory group, e.g. a role group or a BISO.
+type Group struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GroupListOptions filters the result of GroupsService.List.
+type GroupListOptions struct {
+	Name string
+}
+
+// All returns an iterator over all groups matching opts.
+func (s *GroupsService) All(ctx context.Context, opts *GroupListOptions) iter.Seq2[Group, error] {
+	query := url.Values{}
+	if opts != nil {
+		setQuery(query, "name", opts.Name)
+	}
+	return paginate[Group](ctx, s.client, objectPath("groups"), query)
+}
+
+// List returns all groups matching opts.
+func (s *GroupsService) List(ctx context.Context, opts *GroupListOptions) ([]Group, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// Get returns the group with the given ID.
+func (s *GroupsService) Get(ctx context.Context, id string) (*Group, error) {
+	var out Group
+	if err := s.client.do(ctx, http.MethodGet, objectPath("groups", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
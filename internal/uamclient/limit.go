@@ -0,0 +1,54 @@
+package uamclient
+
+import (
+	"context"
+	"math"
+
+	"golang.org/x/time/rate"
+)
+
+// limiter throttles the requests of a client. It caps the number of
+// requests in flight and the request rate across all goroutines sharing the
+// client, i.e. across all resources Terraform handles in parallel.
+type limiter struct {
+	// slots holds one token per request in flight; nil means unlimited.
+	slots chan struct{}
+	// rate is nil when the request rate is unlimited.
+	rate *rate.Limiter
+}
+
+func newLimiter(maxConcurrent int, requestsPerSecond float64) *limiter {
+	l := &limiter{}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	if requestsPerSecond > 0 {
+		burst := max(1, int(math.Ceil(requestsPerSecond)))
+		l.rate = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+	return l
+}
+
+// acquire blocks until a request may be sent or ctx is done. On success the
+// returned release function must be called once the request completed.
+func (l *limiter) acquire(ctx context.Context) (release func(), err error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release = func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
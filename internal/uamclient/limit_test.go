@@ -0,0 +1,80 @@
+package uamclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		writeJSON(t, w, http.StatusOK, Group{ID: "g-1"})
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(Config{Host: srv.URL, MaxConcurrentRequests: 2})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if _, err := c.Groups.Get(t.Context(), "g-1"); err != nil {
+				t.Errorf("Get: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("peak concurrency = %d, want 2", got)
+	}
+}
+
+func TestRequestsPerSecond(t *testing.T) {
+	l := newLimiter(0, 20)
+
+	start := time.Now()
+	for range 30 {
+		release, err := l.acquire(t.Context())
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		release()
+	}
+	// The first 20 requests use the burst, the next 10 need half a second.
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("30 requests at 20/s took %v", elapsed)
+	}
+}
+
+func TestLimiterHonorsContext(t *testing.T) {
+	l := newLimiter(1, 0)
+	release, err := l.acquire(t.Context())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package uamclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ModuleBISOsService manages the BISOs (business information security
+// officers) assigned to modules.
+type ModuleBISOsService service
+
+// ModuleBISO is the assignment of a BISO group to a module.
+type ModuleBISO struct {
+	ApplicationName string `json:"application_name"`
+	ModuleID        string `json:"module_id"`
+	BISOID          string `json:"biso_id"`
+	Reason          string `json:"reason"`
+}
+
+// ModuleBISOAssign is the request body for assigning a BISO to a module.
+type ModuleBISOAssign struct {
+	ApplicationName string `json:"application_name"`
+	Reason          string `json:"reason"`
+}
+
+func moduleBISOPath(moduleID, bisoID string) string {
+	return objectPath("modules", moduleID) + "/bisos/" + url.PathEscape(bisoID)
+}
+
+// All returns an iterator over all BISOs assigned to the module with the
+// given ID.
+func (s *ModuleBISOsService) All(ctx context.Context, moduleID string) iter.Seq2[ModuleBISO, error] {
+	return paginate[ModuleBISO](ctx, s.client, objectPath("modules", moduleID)+"/bisos", nil)
+}
+
+// List returns all BISOs assigned to the module with the given ID.
+func (s *ModuleBISOsService) List(ctx context.Context, moduleID string) ([]ModuleBISO, error) {
+	return collect(s.All(ctx, moduleID))
+}
+
+// Get returns the assignment of a BISO to a module.
+func (s *ModuleBISOsService) Get(ctx context.Context, moduleID, bisoID string) (*ModuleBISO, error) {
+	var out ModuleBISO
+	if err := s.client.do(ctx, http.MethodGet, moduleBISOPath(moduleID, bisoID), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Assign assigns a BISO to a module. Assigning an already assigned BISO
+// replaces the reason of the assignment.
+func (s *ModuleBISOsService) Assign(ctx context.Context, moduleID, bisoID string, in ModuleBISOAssign) (*ModuleBISO, error) {
+	var out ModuleBISO
+	if err := s.client.do(ctx, http.MethodPut, moduleBISOPath(moduleID, bisoID), nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Unassign removes a BISO from a module.
+func (s *ModuleBISOsService) Unassign(ctx context.Context, moduleID, bisoID string) error {
+	return s.client.do(ctx, http.MethodDelete, moduleBISOPath(moduleID, bisoID), nil, nil, nil)
+}
@@ -0,0 +1,45 @@
+package uamclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestModuleBISOsAssign(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	})
+	mux.HandleFunc("PUT /api/v1/modules/{module}/bisos/{biso}", func(w http.ResponseWriter, r *http.Request) {
+		var in ModuleBISOAssign
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		writeJSON(t, w, http.StatusOK, ModuleBISO{
+			ApplicationName: in.ApplicationName,
+			ModuleID:        r.PathValue("module"),
+			BISOID:          r.PathValue("biso"),
+			Reason:          in.Reason,
+		})
+	})
+	mux.HandleFunc("GET /api/v1/modules/{module}/bisos/{biso}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "BISO not assigned"})
+	})
+	c := newTestClient(t, mux)
+
+	biso, err := c.ModuleBISOs.Assign(t.Context(), "m 1", "XZ41234", ModuleBISOAssign{
+		ApplicationName: "Application",
+		Reason:          "AV PWS Blueprint",
+	})
+	if err != nil {
+		t.Fatalf("Assign: %v", err)
+	}
+	if biso.ModuleID != "m 1" || biso.BISOID != "XZ41234" || biso.Reason != "AV PWS Blueprint" {
+		t.Errorf("unexpected assignment %+v", biso)
+	}
+
+	if _, err := c.ModuleBISOs.Get(t.Context(), "m 1", "XZ41234"); !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
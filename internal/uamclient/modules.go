@@ -0,0 +1,85 @@
+package uamclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ModulesService manages the modules of an application.
+type ModulesService service
+
+// Module is a UAM/OIM module, the container for shops and role assignments.
+type Module struct {
+	ID              string `json:"id"`
+	ApplicationName string `json:"application_name"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+}
+
+// ModuleCreate is the request body for creating a module.
+type ModuleCreate struct {
+	ApplicationName string  `json:"application_name"`
+	Name            string  `json:"name"`
+	Description     *string `json:"description,omitempty"`
+}
+
+// ModuleUpdate is the request body for updating a module.
+type ModuleUpdate struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+// ModuleListOptions filters the result of ModulesService.List.
+type ModuleListOptions struct {
+	ApplicationName string
+	Name            string
+}
+
+// All returns an iterator over all modules matching opts.
+func (s *ModulesService) All(ctx context.Context, opts *ModuleListOptions) iter.Seq2[Module, error] {
+	query := url.Values{}
+	if opts != nil {
+		setQuery(query, "application_name", opts.ApplicationName)
+		setQuery(query, "name", opts.Name)
+	}
+	return paginate[Module](ctx, s.client, objectPath("modules"), query)
+}
+
+// List returns all modules matching opts.
+func (s *ModulesService) List(ctx context.Context, opts *ModuleListOptions) ([]Module, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// Get returns the module with the given ID.
+func (s *ModulesService) Get(ctx context.Context, id string) (*Module, error) {
+	var out Module
+	if err := s.client.do(ctx, http.MethodGet, objectPath("modules", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Create creates a new module.
+func (s *ModulesService) Create(ctx context.Context, in ModuleCreate) (*Module, error) {
+	var out Module
+	if err := s.client.do(ctx, http.MethodPost, objectPath("modules"), nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Update replaces the mutable fields of the module with the given ID.
+func (s *ModulesService) Update(ctx context.Context, id string, in ModuleUpdate) (*Module, error) {
+	var out Module
+	if err := s.client.do(ctx, http.MethodPut, objectPath("modules", id), nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Delete deletes the module with the given ID.
+func (s *ModulesService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, http.MethodDelete, objectPath("modules", id), nil, nil, nil)
+}
@@ -0,0 +1,148 @@
+package uamclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry an OAuth2 token is
+// refreshed, so that it does not expire while a request is in flight.
+const tokenExpiryDelta = 30 * time.Second
+
+// OAuthConfig configures authentication with the OAuth2 client credentials
+// grant (RFC 6749, section 4.4).
+type OAuthConfig struct {
+	// TokenURL is the token endpoint of the authorization server.
+	TokenURL string
+	// ClientID and ClientSecret identify the service principal.
+	ClientID     string
+	ClientSecret string
+	// Scopes are requested with every token. Optional.
+	Scopes []string
+}
+
+// oauthSource fetches OAuth2 access tokens and caches them until shortly
+// before they expire.
+type oauthSource struct {
+	tokenURL *url.URL
+	cfg      OAuthConfig
+
+	mu    sync.Mutex
+	token *oauthToken
+}
+
+type oauthToken struct {
+	accessToken string
+	tokenType   string
+	// expiry is zero if the token does not expire.
+	expiry time.Time
+}
+
+// valid reports whether t can still be used at now.
+func (t *oauthToken) valid(now time.Time) bool {
+	return t != nil && (t.expiry.IsZero() || now.Before(t.expiry.Add(-tokenExpiryDelta)))
+}
+
+// authorization returns the Authorization header value for t.
+func (t *oauthToken) authorization() string {
+	if t.tokenType == "" || strings.EqualFold(t.tokenType, "bearer") {
+		return "Bearer " + t.accessToken
+	}
+	return t.tokenType + " " + t.accessToken
+}
+
+func newOAuthSource(cfg OAuthConfig) (*oauthSource, error) {
+	tokenURL, err := url.Parse(cfg.TokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OAuth2 token URL %q: %w", cfg.TokenURL, err)
+	}
+	if tokenURL.Scheme != "http" && tokenURL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid OAuth2 token URL %q: scheme must be http or https", cfg.TokenURL)
+	}
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, errors.New("OAuth2 client ID and client secret must not be empty")
+	}
+	return &oauthSource{tokenURL: tokenURL, cfg: cfg}, nil
+}
+
+// oauthToken returns the cached access token, fetching a new one if there is
+// none yet or it is about to expire.
+func (c *Client) oauthToken(ctx context.Context) (*oauthToken, error) {
+	s := c.oauth
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token.valid(time.Now()) {
+		return s.token, nil
+	}
+
+	token, err := c.fetchOAuthToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch OAuth2 token: %w", err)
+	}
+	s.token = token
+	return token, nil
+}
+
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn is sent as a string by some authorization servers.
+	ExpiresIn json.Number `json:"expires_in"`
+}
+
+// fetchOAuthToken requests a new access token from the token endpoint. The
+// client credentials are sent in the request body, which, unlike HTTP basic
+// authentication, all common authorization servers accept.
+func (c *Client) fetchOAuthToken(ctx context.Context) (*oauthToken, error) {
+	s := c.oauth
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.cfg.ClientID},
+		"client_secret": {s.cfg.ClientSecret},
+	}
+	if len(s.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	issued := time.Now()
+	res, body, err := c.roundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, newAPIError(req, res, body)
+	}
+
+	var out oauthTokenResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("decode token response: %w", err)
+	}
+	if out.AccessToken == "" {
+		return nil, errors.New("token response did not contain an access token")
+	}
+	token := &oauthToken{accessToken: out.AccessToken, tokenType: out.TokenType}
+	if out.ExpiresIn != "" {
+		seconds, err := out.ExpiresIn.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires_in %q in token response", out.ExpiresIn)
+		}
+		if seconds > 0 {
+			token.expiry = issued.Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	return token, nil
+}
@@ -0,0 +1,114 @@
+package uamclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// withOAuth makes a test client authenticate against the token endpoint of
+// the test server instead of signing in.
+func withOAuth(cfg *Config) {
+	cfg.Username, cfg.Password = "", ""
+	cfg.OAuth = &OAuthConfig{
+		TokenURL:     cfg.Host + "/oauth2/token",
+		ClientID:     "terraform",
+		ClientSecret: "secret",
+		Scopes:       []string{"uam.read", "uam.write"},
+	}
+}
+
+func TestOAuthTokenIsCached(t *testing.T) {
+	tokens := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parse form: %v", err)
+		}
+		want := map[string]string{
+			"grant_type":    "client_credentials",
+			"client_id":     "terraform",
+			"client_secret": "secret",
+			"scope":         "uam.read uam.write",
+		}
+		for k, v := range want {
+			if got := r.PostForm.Get(k); got != v {
+				t.Errorf("%s = %q, want %q", k, got, v)
+			}
+		}
+		writeJSON(t, w, http.StatusOK, map[string]any{"access_token": "at-1", "token_type": "bearer", "expires_in": 3600})
+	})
+	mux.HandleFunc("GET /api/v1/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer at-1" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer at-1")
+		}
+		writeJSON(t, w, http.StatusOK, Group{ID: r.PathValue("id")})
+	})
+	c := newTestClient(t, mux, withOAuth)
+
+	for range 3 {
+		if _, err := c.Groups.Get(t.Context(), "g-1"); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+	if tokens != 1 {
+		t.Errorf("got %d token requests, want 1", tokens)
+	}
+}
+
+func TestOAuthTokenIsRefreshedBeforeExpiry(t *testing.T) {
+	tokens := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		// Some authorization servers send expires_in as a string. A token
+		// valid for less than tokenExpiryDelta is refreshed every time.
+		writeJSON(t, w, http.StatusOK, map[string]any{"access_token": "at", "expires_in": "10"})
+	})
+	mux.HandleFunc("GET /api/v1/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, Group{ID: r.PathValue("id")})
+	})
+	c := newTestClient(t, mux, withOAuth)
+
+	for range 2 {
+		if _, err := c.Groups.Get(t.Context(), "g-1"); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+	if tokens != 2 {
+		t.Errorf("got %d token requests, want 2", tokens)
+	}
+}
+
+func TestOAuthTokenError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusUnauthorized, map[string]string{
+			"error":             "invalid_client",
+			"error_description": "Client authentication failed.",
+		})
+	})
+	c := newTestClient(t, mux, withOAuth)
+
+	_, err := c.Groups.Get(t.Context(), "g-1")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("err = %v, want %v", err, ErrUnauthorized)
+	}
+	if want := "fetch OAuth2 token: POST /oauth2/token: status 401: invalid_client: Client authentication failed."; err.Error() != want {
+		t.Errorf("err = %q, want %q", err, want)
+	}
+}
+
+func TestNewClientValidatesOAuth(t *testing.T) {
+	invalid := []Config{
+		{Host: "http://localhost", OAuth: &OAuthConfig{TokenURL: "localhost/token", ClientID: "id", ClientSecret: "secret"}},
+		{Host: "http://localhost", OAuth: &OAuthConfig{TokenURL: "http://localhost/token", ClientID: "id"}},
+		{Host: "http://localhost", Username: "user", OAuth: &OAuthConfig{TokenURL: "http://localhost/token", ClientID: "id", ClientSecret: "secret"}},
+	}
+	for _, cfg := range invalid {
+		if _, err := NewClient(cfg); err == nil {
+			t.Errorf("NewClient(%+v): expected error", cfg.OAuth)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package uamclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// OrdersService manages orders of the local development API (see
+// docker_compose). It backs the uamoim_order resource.
+type OrdersService service
+
+// Order is an order of the development API.
+type Order struct {
+	ID    int         `json:"id,omitempty"`
+	Items []OrderItem `json:"items,omitempty"`
+}
+
+// OrderItem is a single line of an order.
+type OrderItem struct {
+	Coffee   Coffee `json:"coffee"`
+	Quantity int    `json:"quantity"`
+}
+
+// Get returns the order with the given ID.
+func (s *OrdersService) Get(ctx context.Context, id string) (*Order, error) {
+	var out Order
+	if err := s.client.do(ctx, http.MethodGet, "/orders/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Create creates a new order with the given items.
+func (s *OrdersService) Create(ctx context.Context, items []OrderItem) (*Order, error) {
+	var out Order
+	if err := s.client.do(ctx, http.MethodPost, "/orders", nil, items, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Update replaces the items of the order with the given ID.
+func (s *OrdersService) Update(ctx context.Context, id string, items []OrderItem) (*Order, error) {
+	var out Order
+	if err := s.client.do(ctx, http.MethodPut, "/orders/"+url.PathEscape(id), nil, items, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Delete deletes the order with the given ID.
+func (s *OrdersService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, http.MethodDelete, "/orders/"+url.PathEscape(id), nil, nil, nil)
+}
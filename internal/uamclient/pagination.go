@@ -0,0 +1,112 @@
+package uamclient
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// defaultPageSize is the number of items requested per page.
+const defaultPageSize = 100
+
+// page is the envelope returned by the UAM/OIM list endpoints.
+type page[T any] struct {
+	Items []T `json:"items"`
+	// Total is the number of items across all pages, if the API knows it.
+	Total int `json:"total"`
+	// Next links to the next page. It is empty for the last page and for
+	// endpoints that only support offset/limit paging.
+	Next string `json:"next"`
+}
+
+// paginate returns an iterator over all items of the list endpoint p. It
+// follows the next link of a page if there is one and falls back to
+// offset/limit paging otherwise. The iterator yields a non-nil error, e.g.
+// when ctx is cancelled, at most once and stops afterwards.
+func paginate[T any](ctx context.Context, c *Client, p string, query url.Values) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		fail := func(err error) { yield(zero, err) }
+
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("limit", strconv.Itoa(c.pageSize))
+
+		offset := 0
+		var next *url.URL
+		for {
+			if err := ctx.Err(); err != nil {
+				fail(err)
+				return
+			}
+
+			u := next
+			if u == nil {
+				q.Set("offset", strconv.Itoa(offset))
+				u = c.url(p, q)
+			}
+			var pg page[T]
+			if err := c.doURL(ctx, http.MethodGet, u, nil, &pg); err != nil {
+				fail(err)
+				return
+			}
+
+			for _, item := range pg.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			offset += len(pg.Items)
+
+			if pg.Next != "" {
+				n, err := c.nextURL(u, pg.Next)
+				if err != nil {
+					fail(err)
+					return
+				}
+				next = n
+				continue
+			}
+			// A page reached through a link without a link of its own is
+			// the last one.
+			if next != nil || len(pg.Items) < c.pageSize || (pg.Total > 0 && offset >= pg.Total) {
+				return
+			}
+		}
+	}
+}
+
+// nextURL resolves the next link of the page at current. The link must
+// point to the API host and differ from current, so that the session token
+// is not sent elsewhere and paging always progresses.
+func (c *Client) nextURL(current *url.URL, link string) (*url.URL, error) {
+	ref, err := url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next page link %q: %w", link, err)
+	}
+	next := current.ResolveReference(ref)
+	if next.Scheme != c.baseURL.Scheme || next.Host != c.baseURL.Host {
+		return nil, fmt.Errorf("next page link %q does not point to %s", link, c.baseURL.Host)
+	}
+	if next.String() == current.String() {
+		return nil, fmt.Errorf("next page link %q points to the current page", link)
+	}
+	return next, nil
+}
+
+// collect returns all items of seq, or the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
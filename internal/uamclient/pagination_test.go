@@ -0,0 +1,131 @@
+package uamclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// signInHandler accepts any credentials.
+func signInHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: "tok"})
+	}
+}
+
+func TestPaginateOffsetLimit(t *testing.T) {
+	const total = 5
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", signInHandler(t))
+	mux.HandleFunc("GET /api/v1/groups", func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if got := r.URL.Query().Get("name"); got != "KRN" {
+			t.Errorf("name = %q, want %q", got, "KRN")
+		}
+		var pg page[Group]
+		for i := offset; i < min(offset+limit, total); i++ {
+			pg.Items = append(pg.Items, Group{ID: strconv.Itoa(i)})
+		}
+		writeJSON(t, w, http.StatusOK, pg)
+	})
+	c := newTestClient(t, mux)
+	c.pageSize = 2
+
+	groups, err := c.Groups.List(t.Context(), &GroupListOptions{Name: "KRN"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(groups) != total {
+		t.Fatalf("got %d groups, want %d", len(groups), total)
+	}
+	for i, g := range groups {
+		if g.ID != strconv.Itoa(i) {
+			t.Errorf("groups[%d].ID = %q", i, g.ID)
+		}
+	}
+}
+
+func TestPaginateNextLink(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", signInHandler(t))
+	mux.HandleFunc("GET /api/v1/shops", func(w http.ResponseWriter, r *http.Request) {
+		switch cursor := r.URL.Query().Get("cursor"); cursor {
+		case "":
+			writeJSON(t, w, http.StatusOK, page[Shop]{
+				Items: []Shop{{ID: "s-1"}, {ID: "s-2"}},
+				Next:  "/api/v1/shops?cursor=b",
+			})
+		case "b":
+			if got := r.Header.Get("Authorization"); got != "tok" {
+				t.Errorf("Authorization = %q, want %q", got, "tok")
+			}
+			writeJSON(t, w, http.StatusOK, page[Shop]{Items: []Shop{{ID: "s-3"}, {ID: "s-4"}}})
+		default:
+			t.Errorf("unexpected cursor %q", cursor)
+		}
+	})
+	c := newTestClient(t, mux)
+	c.pageSize = 2
+
+	shops, err := c.Shops.List(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(shops) != 4 || shops[3].ID != "s-4" {
+		t.Errorf("unexpected shops %+v", shops)
+	}
+}
+
+func TestPaginateRejectsForeignNextLink(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", signInHandler(t))
+	mux.HandleFunc("GET /api/v1/shops", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, page[Shop]{
+			Items: []Shop{{ID: "s-1"}},
+			Next:  "https://attacker.example.com/api/v1/shops?cursor=b",
+		})
+	})
+	c := newTestClient(t, mux)
+
+	if _, err := c.Shops.List(t.Context(), nil); err == nil {
+		t.Fatal("expected error for next link to another host")
+	}
+}
+
+func TestPaginateStopsOnContextCancel(t *testing.T) {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", signInHandler(t))
+	mux.HandleFunc("GET /api/v1/sod-classes", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		writeJSON(t, w, http.StatusOK, page[SoDClass]{Items: []SoDClass{
+			{ID: fmt.Sprint(offset)}, {ID: fmt.Sprint(offset + 1)},
+		}})
+	})
+	c := newTestClient(t, mux)
+	c.pageSize = 2
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	var got int
+	var err error
+	for _, err = range c.SoDClasses.All(ctx, nil) {
+		if err != nil {
+			break
+		}
+		if got++; got == 3 {
+			cancel()
+		}
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+	if requests != 2 {
+		t.Errorf("got %d page requests, want 2", requests)
+	}
+}
@@ -0,0 +1,140 @@
+package uamclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. The zero value
+// disables retries.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// MinWait and MaxWait bound the exponential backoff between attempts.
+	MinWait time.Duration
+	MaxWait time.Duration
+	// RetryNonIdempotent also retries rate limited requests of methods that
+	// are not idempotent, such as POST. Only enable it if the API rejects
+	// rate limited requests before processing them. Transport and gateway
+	// errors of such requests are never retried.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is the policy the provider uses unless configured
+// otherwise.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinWait:    time.Second,
+	MaxWait:    30 * time.Second,
+}
+
+// idempotentMethods can be repeated without changing the result, so they
+// are retried after transport and gateway errors as well.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// retryWait reports whether the attempt (0-based) of a request, which ended
+// with res or err, should be retried and how long to wait before doing so.
+//
+// Only idempotent methods are retried, because a mutating request may have
+// been applied before the connection broke or the gateway gave up. Rate
+// limited requests of other methods are retried if RetryNonIdempotent is
+// set. Errors that a retry cannot fix, such as an untrusted server
+// certificate, are not retried.
+func (p RetryPolicy) retryWait(ctx context.Context, method string, attempt int, res *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxRetries || ctx.Err() != nil {
+		return 0, false
+	}
+
+	if err != nil {
+		return p.backoff(attempt), idempotentMethods[method] && !permanentError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		if !idempotentMethods[method] && !p.RetryNonIdempotent {
+			return 0, false
+		}
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !idempotentMethods[method] {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if wait, ok := retryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+		// Rather fail than block the run for longer than the user allows.
+		return wait, wait <= p.MaxWait
+	}
+	return p.backoff(attempt), true
+}
+
+// permanentError reports whether the transport error err fails every
+// attempt, e.g. a failed verification of the server certificate.
+func permanentError(err error) bool {
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		recordErr    tls.RecordHeaderError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	return errors.As(err, &verifyErr) || errors.As(err, &recordErr) ||
+		errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
+
+// backoff returns the exponential backoff before retry attempt+1, with
+// jitter so that parallel requests do not retry in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.MinWait
+	for range attempt {
+		wait *= 2
+		if wait >= p.MaxWait {
+			break
+		}
+	}
+	wait = min(wait, p.MaxWait)
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an
+// HTTP date.
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
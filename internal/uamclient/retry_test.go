@@ -0,0 +1,196 @@
+package uamclient
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// withFastRetry makes a test client anonymous and gives it a fast retry
+// policy.
+func withFastRetry(cfg *Config) {
+	cfg.Username, cfg.Password = "", ""
+	cfg.Retry = RetryPolicy{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: 10 * time.Millisecond}
+}
+
+func TestRetryIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+		writeJSON(t, w, http.StatusOK, Group{ID: "g-1"})
+	}), withFastRetry)
+
+	group, err := c.Groups.Get(t.Context(), "g-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if group.ID != "g-1" || calls.Load() != 3 {
+		t.Errorf("got %+v after %d calls", group, calls.Load())
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}), withFastRetry)
+
+	_, err := c.Groups.Get(t.Context(), "g-1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 error, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("got %d calls, want 3", calls.Load())
+	}
+}
+
+func TestRetryDoesNotRepeatMutatingRequests(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}), withFastRetry)
+
+	if _, err := c.Modules.Create(t.Context(), ModuleCreate{Name: "OSKA"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("got %d calls, want 1", calls.Load())
+	}
+}
+
+func TestRetryRateLimitedRequestsHonorRetryAfter(t *testing.T) {
+	for _, retryNonIdempotent := range []bool{false, true} {
+		var calls atomic.Int32
+		c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				http.Error(w, "slow down", http.StatusTooManyRequests)
+				return
+			}
+			writeJSON(t, w, http.StatusCreated, Module{ID: "m-1"})
+		}), withFastRetry, func(cfg *Config) { cfg.Retry.RetryNonIdempotent = retryNonIdempotent })
+
+		// A rate limited POST is only repeated on request.
+		module, err := c.Modules.Create(t.Context(), ModuleCreate{Name: "OSKA"})
+		if !retryNonIdempotent {
+			if !errors.Is(err, ErrRateLimited) || calls.Load() != 1 {
+				t.Errorf("got %v after %d calls, want rate limited after 1", err, calls.Load())
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if module.ID != "m-1" || calls.Load() != 2 {
+			t.Errorf("got %+v after %d calls", module, calls.Load())
+		}
+	}
+}
+
+func TestRetryDoesNotRepeatCertificateErrors(t *testing.T) {
+	var conns atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, Group{ID: "g-1"})
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	// The certificate of the test server is not trusted.
+	c := newTestClient(t, nil, withFastRetry, func(cfg *Config) { cfg.Host = srv.URL })
+	_, err := c.Groups.Get(t.Context(), "g-1")
+	var verifyErr *tls.CertificateVerificationError
+	if !errors.As(err, &verifyErr) {
+		t.Fatalf("expected a certificate verification error, got %v", err)
+	}
+	if conns.Load() != 1 {
+		t.Errorf("got %d connections, want 1", conns.Load())
+	}
+}
+
+func TestRetryAfterBeyondMaxWaitFails(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}), withFastRetry)
+
+	if _, err := c.Groups.Get(t.Context(), "g-1"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("got %d calls, want 1", calls.Load())
+	}
+}
+
+func TestRetryStopsOnContextCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewClient(Config{
+		Host:  srv.URL,
+		Retry: RetryPolicy{MaxRetries: 5, MinWait: time.Hour, MaxWait: time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Groups.Get(ctx, "g-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		wait time.Duration
+		ok   bool
+	}{
+		"":                              {0, false},
+		"120":                           {2 * time.Minute, true},
+		"Sun, 18 Oct 2026 12:00:30 GMT": {30 * time.Second, true},
+		"Sun, 18 Oct 2026 11:00:00 GMT": {0, true},
+		"soon":                          {0, false},
+	}
+	for header, want := range tests {
+		wait, ok := retryAfter(header, now)
+		if wait != want.wait || ok != want.ok {
+			t.Errorf("retryAfter(%q) = %v, %t; want %v, %t", header, wait, ok, want.wait, want.ok)
+		}
+	}
+}
+
+func TestBackoffStaysWithinBounds(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 10, MinWait: time.Second, MaxWait: 8 * time.Second}
+	for attempt := range 10 {
+		upper := min(time.Second<<attempt, 8*time.Second)
+		for range 20 {
+			if got := p.backoff(attempt); got < upper/2 || got > upper {
+				t.Fatalf("backoff(%d) = %v, want within [%v, %v]", attempt, got, upper/2, upper)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package uamclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// RoleAssignmentsService manages the assignment of role groups to modules.
+type RoleAssignmentsService service
+
+// RoleAssignment binds a role group to a module and the shop it is ordered
+// from, together with its SoD class and approval settings.
+type RoleAssignment struct {
+	ID              string `json:"id"`
+	ApplicationName string `json:"application_name"`
+	ModuleID        string `json:"module_id"`
+	GroupID         string `json:"group_id"`
+	ShopID          string `json:"shop_id"`
+	SoDClassID      string `json:"sod_class_id"`
+	OrderFor        string `json:"order_for"`
+	ApprovalFlow    string `json:"approval_flow"`
+	Description     string `json:"description"`
+	CanFachrolle    bool   `json:"can_fachrolle"`
+}
+
+// RoleAssignmentCreate is the request body for creating a role assignment.
+type RoleAssignmentCreate struct {
+	ApplicationName string `json:"application_name"`
+	ModuleID        string `json:"module_id"`
+	GroupID         string `json:"group_id"`
+	ShopID          string `json:"shop_id"`
+	SoDClassID      string `json:"sod_class_id"`
+	OrderFor        string `json:"order_for"`
+	ApprovalFlow    string `json:"approval_flow"`
+	Description     string `json:"description"`
+	CanFachrolle    bool   `json:"can_fachrolle"`
+}
+
+// RoleAssignmentUpdate is the request body for updating a role assignment.
+// The module and group of an assignment cannot be changed.
+type RoleAssignmentUpdate struct {
+	ShopID       string `json:"shop_id"`
+	SoDClassID   string `json:"sod_class_id"`
+	OrderFor     string `json:"order_for"`
+	ApprovalFlow string `json:"approval_flow"`
+	Description  string `json:"description"`
+	CanFachrolle bool   `json:"can_fachrolle"`
+}
+
+// RoleAssignmentListOptions filters the result of RoleAssignmentsService.List.
+type RoleAssignmentListOptions struct {
+	ModuleID string
+	GroupID  string
+}
+
+// All returns an iterator over all role assignments matching opts.
+func (s *RoleAssignmentsService) All(ctx context.Context, opts *RoleAssignmentListOptions) iter.Seq2[RoleAssignment, error] {
+	query := url.Values{}
+	if opts != nil {
+		setQuery(query, "module_id", opts.ModuleID)
+		setQuery(query, "group_id", opts.GroupID)
+	}
+	return paginate[RoleAssignment](ctx, s.client, objectPath("role-assignments"), query)
+}
+
+// List returns all role assignments matching opts.
+func (s *RoleAssignmentsService) List(ctx context.Context, opts *RoleAssignmentListOptions) ([]RoleAssignment, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// Get returns the role assignment with the given ID.
+func (s *RoleAssignmentsService) Get(ctx context.Context, id string) (*RoleAssignment, error) {
+	var out RoleAssignment
+	if err := s.client.do(ctx, http.MethodGet, objectPath("role-assignments", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Create creates a new role assignment.
+func (s *RoleAssignmentsService) Create(ctx context.Context, in RoleAssignmentCreate) (*RoleAssignment, error) {
+	var out RoleAssignment
+	if err := s.client.do(ctx, http.MethodPost, objectPath("role-assignments"), nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Update replaces the mutable fields of the role assignment with the given ID.
+func (s *RoleAssignmentsService) Update(ctx context.Context, id string, in RoleAssignmentUpdate) (*RoleAssignment, error) {
+	var out RoleAssignment
+	if err := s.client.do(ctx, http.MethodPut, objectPath("role-assignments", id), nil, in, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Delete deletes the role assignment with the given ID.
+func (s *RoleAssignmentsService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, http.MethodDelete, objectPath("role-assignments", id), nil, nil, nil)
+}
@@ -0,0 +1,25 @@
+package uamclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServerService describes the UAM/OIM instance the client talks to.
+type ServerService service
+
+// ServerInfo identifies a UAM/OIM instance.
+type ServerInfo struct {
+	// Environment is the stage of the instance, e.g. "TEST" or "PROD".
+	Environment string `json:"environment"`
+	Version     string `json:"version"`
+}
+
+// Info returns the identity of the instance.
+func (s *ServerService) Info(ctx context.Context) (*ServerInfo, error) {
+	var out ServerInfo
+	if err := s.client.do(ctx, http.MethodGet, objectPath("info"), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
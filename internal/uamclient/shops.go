@@ -0,0 +1,52 @@
+package uamclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ShopsService reads shop (catalog) entries.
+type ShopsService service
+
+// Shop is a catalog entry users can order a role from.
+type Shop struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ModuleID    string `json:"module_id"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+}
+
+// ShopListOptions filters the result of ShopsService.List.
+type ShopListOptions struct {
+	ModuleID   string
+	Name       string
+	NamePrefix string
+}
+
+// All returns an iterator over all shops matching opts.
+func (s *ShopsService) All(ctx context.Context, opts *ShopListOptions) iter.Seq2[Shop, error] {
+	query := url.Values{}
+	if opts != nil {
+		setQuery(query, "module_id", opts.ModuleID)
+		setQuery(query, "name", opts.Name)
+		setQuery(query, "name_prefix", opts.NamePrefix)
+	}
+	return paginate[Shop](ctx, s.client, objectPath("shops"), query)
+}
+
+// List returns all shops matching opts.
+func (s *ShopsService) List(ctx context.Context, opts *ShopListOptions) ([]Shop, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// Get returns the shop with the given ID.
+func (s *ShopsService) Get(ctx context.Context, id string) (*Shop, error) {
+	var out Shop
+	if err := s.client.do(ctx, http.MethodGet, objectPath("shops", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,47 @@
+package uamclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// SoDClassesService reads segregation of duties (SoD) classes.
+type SoDClassesService service
+
+// SoDClass is a segregation of duties class, e.g. "Keine SoD Relevanz".
+type SoDClass struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	RiskLevel   string `json:"risk_level"`
+	Description string `json:"description"`
+}
+
+// SoDClassListOptions filters the result of SoDClassesService.List.
+type SoDClassListOptions struct {
+	Name string
+}
+
+// All returns an iterator over all SoD classes matching opts.
+func (s *SoDClassesService) All(ctx context.Context, opts *SoDClassListOptions) iter.Seq2[SoDClass, error] {
+	query := url.Values{}
+	if opts != nil {
+		setQuery(query, "name", opts.Name)
+	}
+	return paginate[SoDClass](ctx, s.client, objectPath("sod-classes"), query)
+}
+
+// List returns all SoD classes matching opts.
+func (s *SoDClassesService) List(ctx context.Context, opts *SoDClassListOptions) ([]SoDClass, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// Get returns the SoD class with the given ID.
+func (s *SoDClassesService) Get(ctx context.Context, id string) (*SoDClass, error) {
+	var out SoDClass
+	if err := s.client.do(ctx, http.MethodGet, objectPath("sod-classes", id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
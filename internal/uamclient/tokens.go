@@ -0,0 +1,130 @@
+package uamclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TokensService issues access tokens for tools outside the provider, using
+// the credentials of the client. The tokens are independent of the one the
+// client uses itself.
+type TokensService service
+
+// Token is an API access token.
+type Token struct {
+	// Value is the token itself.
+	Value string
+	// Authorization is the value of the Authorization header to send it in.
+	Authorization string
+	// Expiry is zero if the API did not say when the token expires.
+	Expiry time.Time
+	// Renewable reports whether the lifetime of the token can be extended
+	// with Refresh.
+	Renewable bool
+}
+
+// ErrNoCredentials is returned when an anonymous client is asked for a
+// token.
+var ErrNoCredentials = errors.New("client has no credentials to issue a token with")
+
+// Issue returns a new token. With username and password it starts a new
+// session, with OAuth2 it requests a new access token.
+func (s *TokensService) Issue(ctx context.Context) (*Token, error) {
+	c := s.client
+	if c.oauth != nil {
+		t, err := c.fetchOAuthToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &Token{Value: t.accessToken, Authorization: t.authorization(), Expiry: t.expiry}, nil
+	}
+	if c.username == "" && c.password == "" {
+		return nil, ErrNoCredentials
+	}
+
+	var out signInResponse
+	in := signInRequest{Username: c.username, Password: c.password}
+	if err := s.post(ctx, "/signin", in, "", &out); err != nil {
+		return nil, err
+	}
+	if out.Token == "" {
+		return nil, errors.New("sign in: response did not contain a token")
+	}
+	return sessionToken(out), nil
+}
+
+// Refresh extends the lifetime of the session token value and returns it
+// with its new expiry. OAuth2 client credentials tokens cannot be refreshed.
+func (s *TokensService) Refresh(ctx context.Context, value string) (*Token, error) {
+	if s.client.oauth != nil {
+		return nil, errors.New("OAuth2 client credentials tokens cannot be refreshed")
+	}
+
+	var out signInResponse
+	if err := s.post(ctx, "/signin/refresh", nil, value, &out); err != nil {
+		return nil, err
+	}
+	if out.Token == "" {
+		out.Token = value
+	}
+	return sessionToken(out), nil
+}
+
+// Revoke ends the session of the token value. OAuth2 tokens are left to
+// expire, as client credentials grants have no standard revocation.
+func (s *TokensService) Revoke(ctx context.Context, value string) error {
+	if s.client.oauth != nil {
+		return nil
+	}
+	return s.post(ctx, "/signout", nil, value, nil)
+}
+
+// post sends a request authenticated with the token value, or not at all if
+// it is empty. The session token of the client is not involved.
+func (s *TokensService) post(ctx context.Context, p string, in any, value string, out any) error {
+	u := s.client.url(p, nil)
+	_, body, err := s.client.send(ctx, http.MethodPost, u, in, value)
+	if err != nil {
+		return err
+	}
+	return decode(http.MethodPost, u, body, out)
+}
+
+// sessionToken returns the token of a sign in response. If the response does
+// not state the lifetime of the token, its expiry is taken from the token
+// itself if it is a JWT.
+func sessionToken(res signInResponse) *Token {
+	t := &Token{Value: res.Token, Authorization: res.Token, Renewable: true}
+	if res.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(res.ExpiresIn) * time.Second)
+	} else {
+		t.Expiry = jwtExpiry(res.Token)
+	}
+	return t
+}
+
+// jwtExpiry returns the exp claim of the JWT token, or the zero time if token
+// is not a JWT or has no exp claim. The signature is not verified, the
+// expiry only serves as a hint.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
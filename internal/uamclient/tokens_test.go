@@ -0,0 +1,121 @@
+package uamclient
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTokensSessionLifecycle(t *testing.T) {
+	sessions := map[string]bool{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /signin", func(w http.ResponseWriter, r *http.Request) {
+		token := fmt.Sprintf("tok-%d", len(sessions)+1)
+		sessions[token] = true
+		writeJSON(t, w, http.StatusOK, signInResponse{Token: token, ExpiresIn: 600})
+	})
+	mux.HandleFunc("POST /signin/refresh", func(w http.ResponseWriter, r *http.Request) {
+		if !sessions[r.Header.Get("Authorization")] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(t, w, http.StatusOK, signInResponse{ExpiresIn: 1200})
+	})
+	mux.HandleFunc("POST /signout", func(w http.ResponseWriter, r *http.Request) {
+		delete(sessions, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /api/v1/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, Group{ID: r.PathValue("id")})
+	})
+	c := newTestClient(t, mux)
+	ctx := t.Context()
+
+	// The client signs in on its own, the token is a separate session.
+	if _, err := c.Groups.Get(ctx, "g-1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	token, err := c.Tokens.Issue(ctx)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if token.Value != "tok-2" || token.Authorization != "tok-2" || !token.Renewable {
+		t.Errorf("unexpected token %+v", token)
+	}
+	if d := time.Until(token.Expiry); d < 9*time.Minute || d > 10*time.Minute {
+		t.Errorf("token expires in %s, want 10m", d)
+	}
+
+	refreshed, err := c.Tokens.Refresh(ctx, token.Value)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if refreshed.Value != token.Value || !refreshed.Expiry.After(token.Expiry) {
+		t.Errorf("refreshed token %+v, issued %+v", refreshed, token)
+	}
+
+	if err := c.Tokens.Revoke(ctx, token.Value); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if sessions["tok-2"] || !sessions["tok-1"] {
+		t.Errorf("sessions after revoke = %v, want only the client session", sessions)
+	}
+	if _, err := c.Tokens.Refresh(ctx, token.Value); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Refresh after revoke: err = %v, want %v", err, ErrUnauthorized)
+	}
+}
+
+func TestTokensOAuth(t *testing.T) {
+	tokens := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		writeJSON(t, w, http.StatusOK, map[string]any{"access_token": fmt.Sprintf("at-%d", tokens), "expires_in": 3600})
+	})
+	c := newTestClient(t, mux, withOAuth)
+	ctx := t.Context()
+
+	for range 2 {
+		token, err := c.Tokens.Issue(ctx)
+		if err != nil {
+			t.Fatalf("Issue: %v", err)
+		}
+		if token.Authorization != "Bearer "+token.Value || token.Renewable || token.Expiry.IsZero() {
+			t.Errorf("unexpected token %+v", token)
+		}
+	}
+	if tokens != 2 {
+		t.Errorf("got %d token requests, want a new token per Issue", tokens)
+	}
+	if _, err := c.Tokens.Refresh(ctx, "at-1"); err == nil {
+		t.Error("Refresh: expected error for OAuth2 token")
+	}
+	if err := c.Tokens.Revoke(ctx, "at-1"); err != nil {
+		t.Errorf("Revoke: %v", err)
+	}
+}
+
+func TestTokensRequireCredentials(t *testing.T) {
+	c, err := NewClient(Config{Host: "http://localhost"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := c.Tokens.Issue(t.Context()); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Issue: err = %v, want %v", err, ErrNoCredentials)
+	}
+}
+
+func TestJWTExpiry(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"terraform","exp":1767225600}`))
+	if got, want := jwtExpiry("header."+payload+".signature"), time.Unix(1767225600, 0); !got.Equal(want) {
+		t.Errorf("jwtExpiry = %s, want %s", got, want)
+	}
+	for _, token := range []string{"opaque", "a.b.c", "header." + base64.RawURLEncoding.EncodeToString([]byte(`{}`)) + ".sig"} {
+		if got := jwtExpiry(token); !got.IsZero() {
+			t.Errorf("jwtExpiry(%q) = %s, want zero", token, got)
+		}
+	}
+}
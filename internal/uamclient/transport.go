@@ -0,0 +1,206 @@
+package uamclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TLSConfig configures how the client verifies the API server and
+// authenticates itself with a client certificate. The CA certificates are
+// also trusted for other hosts, e.g. the OAuth2 token endpoint, but the other
+// settings only apply to the API host.
+type TLSConfig struct {
+	// CACertFile and CACertPEM add PEM encoded CA certificates to the
+	// system roots used to verify the server. Both may be set.
+	CACertFile string
+	CACertPEM  string
+	// ClientCertFile and ClientKeyFile are the PEM encoded certificate and
+	// key for mutual TLS. Either both or none must be set.
+	ClientCertFile string
+	ClientKeyFile  string
+	// ServerName overrides the name the server certificate is verified
+	// against, e.g. when connecting through an IP address.
+	ServerName string
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+}
+
+// tlsClientConfigs returns the crypto/tls configuration for the API host and
+// the one for all other hosts, e.g. the OAuth2 token endpoint and HTTPS
+// proxies. Both trust the configured CAs, but only the API host gets the
+// server name override, the client certificate and InsecureSkipVerify.
+func (cfg TLSConfig) tlsClientConfigs() (api, other *tls.Config, err error) {
+	other = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CACertFile != "" || cfg.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if cfg.CACertFile != "" {
+			pem, err := os.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, nil, fmt.Errorf("read CA certificate: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, nil, fmt.Errorf("no PEM encoded certificate found in %s", cfg.CACertFile)
+			}
+		}
+		if cfg.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(cfg.CACertPEM)) {
+			return nil, nil, errors.New("no PEM encoded certificate found in CA certificate PEM")
+		}
+		other.RootCAs = pool
+	}
+
+	api = other.Clone()
+	api.ServerName = cfg.ServerName
+	api.InsecureSkipVerify = cfg.InsecureSkipVerify
+
+	if (cfg.ClientCertFile == "") != (cfg.ClientKeyFile == "") {
+		return nil, nil, errors.New("client certificate and key must be set together")
+	}
+	if cfg.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		api.Certificates = []tls.Certificate{cert}
+	}
+	return api, other, nil
+}
+
+// hostTransport sends the requests to the API host with the TLS settings of
+// the API, and all other requests, e.g. to the OAuth2 token endpoint, without
+// them.
+type hostTransport struct {
+	// apiAddr is the host and port of the API, see hostAddr.
+	apiAddr string
+	api     *http.Transport
+	other   *http.Transport
+}
+
+func (t *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if hostAddr(req.URL) == t.apiAddr {
+		return t.api.RoundTrip(req)
+	}
+	return t.other.RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of both transports.
+func (t *hostTransport) CloseIdleConnections() {
+	t.api.CloseIdleConnections()
+	t.other.CloseIdleConnections()
+}
+
+// hostAddr returns the lower case host and port of u, with the default port
+// of its scheme if it has none, as net/http dials it.
+func hostAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(strings.ToLower(u.Hostname()), port)
+}
+
+// newTransport returns the HTTP transport for cfg and the API at baseURL,
+// based on the defaults of net/http.
+func newTransport(cfg Config, baseURL *url.URL) (*hostTransport, error) {
+	apiTLS, otherTLS, err := cfg.TLS.tlsClientConfigs()
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := proxyFunc(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &hostTransport{apiAddr: hostAddr(baseURL)}
+	t.other = baseTransport(otherTLS, proxy)
+	t.api = baseTransport(apiTLS, proxy)
+	// net/http also uses TLSClientConfig for an HTTPS proxy, so the first TLS
+	// connection is set up here, without the API settings unless it goes to
+	// the API itself. TLSClientConfig still applies to the API host when it
+	// is reached through a proxy tunnel.
+	// Proxies are spoken to with HTTP/1.1.
+	proxyTLS := otherTLS.Clone()
+	dial := t.api.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	t.api.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		tc := proxyTLS
+		if addr == t.apiAddr {
+			tc = t.api.TLSClientConfig
+		}
+		tc = tc.Clone()
+		if tc.ServerName == "" {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+			tc.ServerName = host
+		}
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		tlsConn := tls.Client(conn, tc)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tlsConn, nil
+	}
+	return t, nil
+}
+
+// baseTransport returns a clone of the default transport of net/http with
+// the given TLS configuration and proxy. A nil proxy keeps the default.
+func baseTransport(tc *tls.Config, proxy func(*http.Request) (*url.URL, error)) *http.Transport {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = t.Clone()
+	}
+	transport.TLSClientConfig = tc
+	if proxy != nil {
+		transport.Proxy = proxy
+	}
+	return transport
+}
+
+// proxyFunc returns the proxy selection for cfg, or nil to use the proxy
+// environment variables.
+func proxyFunc(cfg Config) (func(*http.Request) (*url.URL, error), error) {
+	if cfg.ProxyURL == "" && cfg.NoProxy == "" {
+		return nil, nil
+	}
+	proxy := httpproxy.FromEnvironment()
+	if cfg.ProxyURL != "" {
+		u, err := url.Parse(cfg.ProxyURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: need an http, https or socks5 URL with a host", cfg.ProxyURL)
+		}
+		proxy.HTTPProxy = cfg.ProxyURL
+		proxy.HTTPSProxy = cfg.ProxyURL
+	}
+	if cfg.NoProxy != "" {
+		proxy.NoProxy = cfg.NoProxy
+	}
+	proxyURL := proxy.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyURL(req.URL)
+	}, nil
+}
@@ -0,0 +1,258 @@
+package uamclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeClientCert writes a self-signed client certificate and its key to
+// dir and returns the certificate and both file names.
+func writeClientCert(t *testing.T, dir string) (cert *x509.Certificate, certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "PRIVATE KEY", keyDER)
+	return cert, certFile, keyFile
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(name, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func TestClientTLS(t *testing.T) {
+	dir := t.TempDir()
+	clientCert, certFile, keyFile := writeClientCert(t, dir)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, Group{ID: "g-1"})
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	caFile := filepath.Join(dir, "ca.crt")
+	writePEM(t, caFile, "CERTIFICATE", srv.Certificate().Raw)
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		tls     TLSConfig
+		wantErr bool
+	}{
+		{"unknown CA", TLSConfig{ClientCertFile: certFile, ClientKeyFile: keyFile}, true},
+		{"no client certificate", TLSConfig{CACertFile: caFile}, true},
+		{"CA file", TLSConfig{CACertFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile}, false},
+		{"CA PEM", TLSConfig{CACertPEM: string(caPEM), ClientCertFile: certFile, ClientKeyFile: keyFile}, false},
+		// The httptest certificate is valid for example.com.
+		{"server name", TLSConfig{CACertFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile, ServerName: "example.com"}, false},
+		{"server name mismatch", TLSConfig{CACertFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile, ServerName: "oim.internal"}, true},
+		{"insecure", TLSConfig{InsecureSkipVerify: true, ClientCertFile: certFile, ClientKeyFile: keyFile}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(Config{Host: srv.URL, TLS: tt.tls})
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			_, err = c.Groups.Get(t.Context(), "g-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Get: err = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// serverCert returns a self-signed server certificate that is only valid
+// for dnsName.
+func serverCert(t *testing.T, dnsName string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// The server name and the client certificate are meant for the API host,
+// so they must not be used for the OAuth2 token endpoint.
+func TestClientTLSWithOAuth(t *testing.T) {
+	dir := t.TempDir()
+	clientCert, certFile, keyFile := writeClientCert(t, dir)
+
+	api := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer oauth-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		writeJSON(t, w, http.StatusOK, Group{ID: "g-1"})
+	}))
+	apiCert := serverCert(t, "oim.internal")
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	api.TLS = &tls.Config{
+		Certificates: []tls.Certificate{apiCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	api.Config.ErrorLog = log.New(io.Discard, "", 0)
+	api.StartTLS()
+	t.Cleanup(api.Close)
+
+	token := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) != 0 {
+			t.Error("client certificate sent to the token endpoint")
+		}
+		writeJSON(t, w, http.StatusOK, oauthTokenResponse{AccessToken: "oauth-token", TokenType: "Bearer"})
+	}))
+	token.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	token.StartTLS()
+	t.Cleanup(token.Close)
+
+	caFile := filepath.Join(dir, "ca.crt")
+	writePEM(t, caFile, "CERTIFICATE", apiCert.Certificate[0])
+	c, err := NewClient(Config{
+		Host: api.URL,
+		OAuth: &OAuthConfig{
+			TokenURL:     token.URL + "/oauth2/token",
+			ClientID:     "terraform",
+			ClientSecret: "secret",
+		},
+		TLS: TLSConfig{
+			CACertFile:     caFile,
+			CACertPEM:      string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: token.Certificate().Raw})),
+			ClientCertFile: certFile,
+			ClientKeyFile:  keyFile,
+			// The token endpoint has no certificate for this name.
+			ServerName: "oim.internal",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := c.Groups.Get(t.Context(), "g-1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+}
+
+func TestNewClientValidatesTLS(t *testing.T) {
+	dir := t.TempDir()
+	_, certFile, _ := writeClientCert(t, dir)
+
+	invalid := []TLSConfig{
+		{CACertFile: filepath.Join(dir, "missing.crt")},
+		{CACertPEM: "not a certificate"},
+		{ClientCertFile: certFile},
+		{ClientCertFile: certFile, ClientKeyFile: certFile},
+	}
+	for _, cfg := range invalid {
+		if _, err := NewClient(Config{Host: "https://localhost", TLS: cfg}); err == nil {
+			t.Errorf("NewClient(%+v): expected error", cfg)
+		}
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	transport, err := newTransport(Config{ProxyURL: "http://proxy.example.com:3128", NoProxy: ".internal,10.0.0.0/8"},
+		mustParseURL(t, "https://oim.example.com"))
+	if err != nil {
+		t.Fatalf("newTransport: %v", err)
+	}
+
+	tests := map[string]string{
+		"https://oim.example.com/api/v1/groups": "http://proxy.example.com:3128",
+		"https://oim.corp.internal/api/v1":      "",
+		"https://10.1.2.3/api/v1":               "",
+	}
+	for target, want := range tests {
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tr := range []*http.Transport{transport.api, transport.other} {
+			proxy, err := tr.Proxy(req)
+			if err != nil {
+				t.Fatalf("Proxy(%s): %v", target, err)
+			}
+			got := ""
+			if proxy != nil {
+				got = proxy.String()
+			}
+			if got != want {
+				t.Errorf("Proxy(%s) = %q, want %q", target, got, want)
+			}
+		}
+	}
+
+	for _, proxyURL := range []string{"proxy.example.com:3128", "ftp://proxy.example.com"} {
+		if _, err := newTransport(Config{ProxyURL: proxyURL}, mustParseURL(t, "https://oim.example.com")); err == nil {
+			t.Errorf("newTransport(%q): expected error", proxyURL)
+		}
+	}
+}